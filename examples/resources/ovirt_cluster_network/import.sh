@@ -0,0 +1,2 @@
+# Import a cluster network using the cluster ID and network ID from the oVirt Engine.
+terraform import ovirt_cluster_network.test 3b940b57-d3a5-448e-9bb3-0d73b76fbb08/7f7f43a8-7fc9-439e-96a0-2cb1737f9234
@@ -0,0 +1,10 @@
+resource "ovirt_disk_download" "test" {
+  disk_id          = var.disk_id
+  destination_file = "./disk.raw"
+  format           = "raw"
+  compute_sha256   = true
+}
+
+output "disk_sha256" {
+  value = ovirt_disk_download.test.sha256
+}
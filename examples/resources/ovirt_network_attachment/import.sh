@@ -0,0 +1,2 @@
+# Import a network attachment using the host ID, host NIC ID and network attachment ID from the oVirt Engine.
+terraform import ovirt_network_attachment.test 5b8c2a4e-1d7f-4c3a-9e2b-6f0a8d1c3e5f/0c9e1f3a-7b2d-4e6c-8a5f-2d4b6c8e0a1b/9e7d5c3b-1a2f-4b6d-8c0e-4f6a8b0c2d4e
@@ -1,6 +1,6 @@
 module github.com/ovirt/terraform-provider-ovirt/v2
 
-go 1.18
+go 1.22
 
 require (
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
@@ -8,7 +8,9 @@ require (
 	github.com/hashicorp/terraform-plugin-docs v0.14.1
 	github.com/hashicorp/terraform-plugin-log v0.8.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.25.0
+	github.com/klauspost/compress v1.18.0
 	github.com/ovirt/go-ovirt-client-log/v3 v3.0.0
+	github.com/ulikunitz/xz v0.5.15
 	github.com/yosefsatrioaji/go-ovirt-client/v3 v3.5.22
 )
 
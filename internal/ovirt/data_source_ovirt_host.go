@@ -0,0 +1,137 @@
+package ovirt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func (p *provider) hostDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: p.hostDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"host_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"host_id", "name"},
+				Description:      "ID of the host to look up. Either host_id or name must be set.",
+				ValidateDiagFunc: validateUUID,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"host_id", "name"},
+				Description:      "Name of the host to look up. Either host_id or name must be set.",
+				ValidateDiagFunc: validateNonEmpty,
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the cluster the host belongs to.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the host.",
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Comment of the host.",
+			},
+			"nics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of host nics",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of nic host",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of nic host",
+						},
+					},
+				},
+			},
+		},
+		Description: `This data source looks up a single host by its ID or name.`,
+	}
+}
+
+func (p *provider) hostDataSourceRead(
+	ctx context.Context,
+	data *schema.ResourceData,
+	_ interface{},
+) diag.Diagnostics {
+	client := p.client.WithContext(ctx)
+
+	var host ovirtclient.Host
+	if hostID, ok := data.GetOk("host_id"); ok {
+		var err error
+		host, err = client.GetHost(ovirtclient.HostID(hostID.(string)))
+		if err != nil {
+			return errorToDiags(fmt.Sprintf("get host %s", hostID), err)
+		}
+	} else {
+		name := data.Get("name").(string)
+		allHosts, err := client.ListHosts()
+		if err != nil {
+			return errorToDiags("list all hosts", err)
+		}
+		for _, h := range allHosts {
+			if h.Name() != name {
+				continue
+			}
+			if host != nil {
+				return diag.Diagnostics{
+					diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  fmt.Sprintf("Multiple hosts found with name %s", name),
+						Detail:   "Please use host_id to select the host.",
+					},
+				}
+			}
+			host = h
+		}
+		if host == nil {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("No host found with name %s", name),
+				},
+			}
+		}
+	}
+
+	nics := make([]map[string]interface{}, 0)
+	hostNics, err := host.HostNICs()
+	if err != nil {
+		return errorToDiags("list host nics", err)
+	}
+	for _, nic := range hostNics {
+		nicMap := make(map[string]interface{}, 0)
+		nicMap["id"] = nic.ID()
+		nicMap["name"] = nic.Name()
+		nics = append(nics, nicMap)
+	}
+
+	diags := diag.Diagnostics{}
+	data.SetId(string(host.ID()))
+	diags = setResourceField(data, "host_id", string(host.ID()), diags)
+	diags = setResourceField(data, "name", host.Name(), diags)
+	diags = setResourceField(data, "cluster_id", string(host.ClusterID()), diags)
+	diags = setResourceField(data, "status", string(host.Status()), diags)
+	diags = setResourceField(data, "comment", host.Comment(), diags)
+	diags = setResourceField(data, "nics", nics, diags)
+	return diags
+}
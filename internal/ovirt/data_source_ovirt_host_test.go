@@ -0,0 +1,56 @@
+package ovirt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestHostDataSource(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	hosts, err := p.getTestHelper().GetClient().ListHosts()
+	if err != nil {
+		t.Fatalf("Failed to list hosts (%v)", err)
+	}
+	host := hosts[0]
+
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+data "ovirt_host" "by_id" {
+	host_id = "%s"
+}
+
+data "ovirt_host" "by_name" {
+	name = "%s"
+}
+`,
+		host.ID(),
+		host.Name(),
+	)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: p.getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ovirt_host.by_id", "name", host.Name()),
+					resource.TestCheckResourceAttr("data.ovirt_host.by_id", "cluster_id", string(host.ClusterID())),
+					resource.TestCheckResourceAttr("data.ovirt_host.by_id", "status", string(host.Status())),
+					resource.TestCheckResourceAttr("data.ovirt_host.by_name", "host_id", string(host.ID())),
+					resource.TestCheckResourceAttrPair(
+						"data.ovirt_host.by_name", "nics.#",
+						"data.ovirt_host.by_id", "nics.#",
+					),
+				),
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package ovirt
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,14 +15,19 @@ func (p *provider) storageDomainDataSource() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"storage_domain_id": {
 				Type:             schema.TypeString,
-				Description:      "ID of the oVirt VM.",
-				Required:         true,
+				Description:      "ID of the oVirt Storage Domain. Either storage_domain_id or storage_domain_name must be set.",
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"storage_domain_id", "storage_domain_name"},
 				ValidateDiagFunc: validateUUID,
 			},
 			"storage_domain_name": {
-				Type:        schema.TypeString,
-				Description: "Name of the oVirt Storage Domain.",
-				Computed:    true,
+				Type:             schema.TypeString,
+				Description:      "Name of the oVirt Storage Domain. Either storage_domain_id or storage_domain_name must be set.",
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"storage_domain_id", "storage_domain_name"},
+				ValidateDiagFunc: validateNonEmpty,
 			},
 			"available": {
 				Type:        schema.TypeInt,
@@ -44,7 +50,7 @@ func (p *provider) storageDomainDataSource() *schema.Resource {
 				Description: "External status of the Storage Domain.",
 			},
 		},
-		Description: `This data source get storage domain details.`,
+		Description: `This data source looks up a single storage domain by its ID or name.`,
 	}
 }
 
@@ -54,17 +60,52 @@ func (p *provider) storageDomainDataSourceRead(
 	_ interface{},
 ) diag.Diagnostics {
 	client := p.client.WithContext(ctx)
-	storageDomainId := data.Get("storage_domain_id").(string)
 
-	storageDomain, err := client.GetStorageDomain(ovirtclient.StorageDomainID(storageDomainId))
-	if err != nil {
-		return errorToDiags("getting storage domain", err)
+	var storageDomain ovirtclient.StorageDomain
+	if storageDomainID, ok := data.GetOk("storage_domain_id"); ok {
+		var err error
+		storageDomain, err = client.GetStorageDomain(ovirtclient.StorageDomainID(storageDomainID.(string)))
+		if err != nil {
+			return errorToDiags("getting storage domain", err)
+		}
+	} else {
+		name := data.Get("storage_domain_name").(string)
+		allStorageDomains, err := client.ListStorageDomains()
+		if err != nil {
+			return errorToDiags("list all storage domains", err)
+		}
+		for _, sd := range allStorageDomains {
+			if sd.Name() != name {
+				continue
+			}
+			if storageDomain != nil {
+				return diag.Diagnostics{
+					diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  fmt.Sprintf("Multiple storage domains found with name %s", name),
+						Detail:   "Please use storage_domain_id to select the storage domain.",
+					},
+				}
+			}
+			storageDomain = sd
+		}
+		if storageDomain == nil {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("No storage domain found with name %s", name),
+				},
+			}
+		}
 	}
-	data.Set("storage_domain_name", storageDomain.Name())
-	data.Set("available", storageDomain.Available())
-	data.Set("storage_type", storageDomain.StorageType())
-	data.Set("status", storageDomain.Status())
-	data.Set("external_status", storageDomain.ExternalStatus())
-	data.SetId(storageDomainId)
-	return nil
+
+	diags := diag.Diagnostics{}
+	data.SetId(string(storageDomain.ID()))
+	diags = setResourceField(data, "storage_domain_id", string(storageDomain.ID()), diags)
+	diags = setResourceField(data, "storage_domain_name", storageDomain.Name(), diags)
+	diags = setResourceField(data, "available", int(storageDomain.Available()), diags)
+	diags = setResourceField(data, "storage_type", string(storageDomain.StorageType()), diags)
+	diags = setResourceField(data, "status", string(storageDomain.Status()), diags)
+	diags = setResourceField(data, "external_status", string(storageDomain.ExternalStatus()), diags)
+	return diags
 }
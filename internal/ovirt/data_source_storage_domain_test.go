@@ -0,0 +1,77 @@
+package ovirt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStorageDomainDataSource(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	storageDomain, err := p.getTestHelper().GetClient().GetStorageDomain(p.getTestHelper().GetStorageDomainID())
+	if err != nil {
+		t.Fatalf("Failed to get storage domain (%v)", err)
+	}
+
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+data "ovirt_storage_domain" "by_id" {
+	storage_domain_id = "%s"
+}
+
+data "ovirt_storage_domain" "by_name" {
+	storage_domain_name = "%s"
+}
+
+data "ovirt_storage_domains" "by_name" {
+	name = "%s"
+}
+
+data "ovirt_storage_domains" "by_type" {
+	storage_type = "%s"
+	status       = "%s"
+}
+`,
+		storageDomain.ID(),
+		storageDomain.Name(),
+		storageDomain.Name(),
+		storageDomain.StorageType(),
+		storageDomain.Status(),
+	)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: p.getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ovirt_storage_domain.by_id", "storage_domain_name", storageDomain.Name(),
+					),
+					resource.TestCheckResourceAttr(
+						"data.ovirt_storage_domain.by_id", "storage_type", string(storageDomain.StorageType()),
+					),
+					resource.TestCheckResourceAttr(
+						"data.ovirt_storage_domain.by_name", "storage_domain_id", string(storageDomain.ID()),
+					),
+					resource.TestCheckResourceAttr("data.ovirt_storage_domains.by_name", "storage_domains.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.ovirt_storage_domains.by_name", "storage_domains.0.id", string(storageDomain.ID()),
+					),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.ovirt_storage_domains.by_type",
+						"storage_domains.*",
+						map[string]string{"id": string(storageDomain.ID())},
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,146 @@
+package ovirt
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func (p *provider) storageDomainsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: p.storageDomainsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return storage domains with this name.",
+				ValidateDiagFunc: validateNonEmpty,
+			},
+			"storage_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return storage domains of this storage type.",
+				ValidateDiagFunc: validateEnum(storageDomainTypeValues()),
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return storage domains with this status.",
+				ValidateDiagFunc: validateEnum(storageDomainStatusValues()),
+			},
+			"storage_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching storage domains, ordered by available space with the most free space first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the Storage Domain.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the Storage Domain.",
+						},
+						"available": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Available space in the Storage Domain.",
+						},
+						"storage_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the Storage Domain.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the Storage Domain.",
+						},
+						"external_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "External status of the Storage Domain.",
+						},
+					},
+				},
+			},
+		},
+		Description: `This data source retrieves a list of storage domains, optionally filtered by name, storage type and status.`,
+	}
+}
+
+func storageDomainTypeValues() []string {
+	values := ovirtclient.StorageDomainTypeValues()
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
+
+func storageDomainStatusValues() []string {
+	values := ovirtclient.StorageDomainStatusValues()
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
+
+func (p *provider) storageDomainsDataSourceRead(
+	ctx context.Context,
+	data *schema.ResourceData,
+	_ interface{},
+) diag.Diagnostics {
+	client := p.client.WithContext(ctx)
+	allStorageDomains, err := client.ListStorageDomains()
+	if err != nil {
+		return errorToDiags("list all storage domains", err)
+	}
+
+	name := data.Get("name").(string)
+	storageType := data.Get("storage_type").(string)
+	status := data.Get("status").(string)
+	var storageDomains []ovirtclient.StorageDomain
+	for _, storageDomain := range allStorageDomains {
+		if name != "" && storageDomain.Name() != name {
+			continue
+		}
+		if storageType != "" && string(storageDomain.StorageType()) != storageType {
+			continue
+		}
+		if status != "" && string(storageDomain.Status()) != status {
+			continue
+		}
+		storageDomains = append(storageDomains, storageDomain)
+	}
+	sort.SliceStable(storageDomains, func(i, j int) bool {
+		if storageDomains[i].Available() != storageDomains[j].Available() {
+			return storageDomains[i].Available() > storageDomains[j].Available()
+		}
+		return storageDomains[i].ID() < storageDomains[j].ID()
+	})
+
+	storageDomainList := make([]map[string]interface{}, 0)
+	for _, storageDomain := range storageDomains {
+		storageDomainMap := make(map[string]interface{}, 0)
+		storageDomainMap["id"] = string(storageDomain.ID())
+		storageDomainMap["name"] = storageDomain.Name()
+		storageDomainMap["available"] = int(storageDomain.Available())
+		storageDomainMap["storage_type"] = string(storageDomain.StorageType())
+		storageDomainMap["status"] = string(storageDomain.Status())
+		storageDomainMap["external_status"] = string(storageDomain.ExternalStatus())
+		storageDomainList = append(storageDomainList, storageDomainMap)
+	}
+	if err := data.Set("storage_domains", storageDomainList); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId("storage_domains")
+	return nil
+}
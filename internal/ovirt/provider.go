@@ -126,6 +126,7 @@ func (p *provider) getProvider() *schema.Provider {
 			"ovirt_disk_resize":              p.diskResizeResource(),
 			"ovirt_vm_disks_resize":          p.vmDisksResizeResource(),
 			"ovirt_disk_from_image":          p.diskFromImageResource(),
+			"ovirt_disk_download":            p.diskDownloadResource(),
 			"ovirt_disk_attachment":          p.diskAttachmentResource(),
 			"ovirt_disk_attachments":         p.diskAttachmentsResource(),
 			"ovirt_nic":                      p.nicResource(),
@@ -141,10 +142,12 @@ func (p *provider) getProvider() *schema.Provider {
 			"ovirt_disk_attachments":          p.diskAttachmentsDataSource(),
 			"ovirt_template_disk_attachments": p.templateDiskAttachmentsDataSource(),
 			"ovirt_cluster_hosts":             p.clusterHostsDataSource(),
+			"ovirt_host":                      p.hostDataSource(),
 			"ovirt_templates":                 p.templatesDataSource(),
 			"ovirt_affinity_group":            p.affinityGroupDataSource(),
 			"ovirt_wait_for_ip":               p.waitForIPDataSource(),
 			"ovirt_storage_domain":            p.storageDomainDataSource(),
+			"ovirt_storage_domains":           p.storageDomainsDataSource(),
 			"ovirt_vnics_list":                p.vnicListDataSource(),
 			"ovirt_cluster_list":              p.clusterListDataSource(),
 			"ovirt_datacenter_list":           p.datacenterListDataSource(),
@@ -2,6 +2,8 @@ package ovirt
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -37,8 +39,11 @@ func (p *provider) clusterNetworkResource() *schema.Resource {
 		CreateContext: p.clusterNetworkCreate,
 		ReadContext:   p.clusterNetworkRead,
 		DeleteContext: p.clusterNetworkDelete,
-		Schema:        clusterNetworkSchema,
-		Description:   "The ovirt_cluster_network resource attaches a network to a cluster in oVirt.",
+		Importer: &schema.ResourceImporter{
+			StateContext: p.clusterNetworkImport,
+		},
+		Schema:      clusterNetworkSchema,
+		Description: "The ovirt_cluster_network resource attaches a network to a cluster in oVirt. Existing cluster networks can be imported using `ClusterID/NetworkID` or the resource ID in the form `ClusterID_NetworkID`.",
 	}
 }
 
@@ -68,6 +73,10 @@ func (p *provider) clusterNetworkRead(ctx context.Context, data *schema.Resource
 		ovirtclient.NetworkID(networkID),
 	)
 	if err != nil {
+		if isNotFound(err) {
+			data.SetId("")
+			return nil
+		}
 		return errorToDiags("get cluster network", err)
 	}
 	return clusterNetworkResourceUpdate(clusterNetwork, data)
@@ -81,13 +90,49 @@ func (p *provider) clusterNetworkDelete(ctx context.Context, data *schema.Resour
 		ovirtclient.ClusterID(clusterID),
 		ovirtclient.NetworkID(networkID),
 	)
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		return errorToDiags("remove cluster network", err)
 	}
 	data.SetId("")
 	return nil
 }
 
+func (p *provider) clusterNetworkImport(ctx context.Context, data *schema.ResourceData, _ interface{}) (
+	[]*schema.ResourceData,
+	error,
+) {
+	client := p.client.WithContext(ctx)
+	importID := data.Id()
+
+	clusterID, networkID, err := parseClusterNetworkImportID(importID)
+	if err != nil {
+		return nil, err
+	}
+	clusterNetwork, err := client.ClusterNetworkGet(clusterID, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import cluster network %s (%w)", importID, err)
+	}
+	if err := diagsToError(clusterNetworkResourceUpdate(clusterNetwork, data)); err != nil {
+		return nil, fmt.Errorf("failed to import cluster network %s (%w)", importID, err)
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
+// parseClusterNetworkImportID splits an import ID into the cluster and network ID. Both ClusterID/NetworkID and the
+// ClusterID_NetworkID form used as the resource ID are accepted as UUIDs never contain either separator.
+func parseClusterNetworkImportID(importID string) (ovirtclient.ClusterID, ovirtclient.NetworkID, error) {
+	parts := strings.FieldsFunc(importID, func(r rune) bool {
+		return r == '/' || r == '_'
+	})
+	if len(parts) != 2 || strings.Count(importID, "/")+strings.Count(importID, "_") != 1 {
+		return "", "", fmt.Errorf(
+			"invalid import specification %s, the ID should be specified as: ClusterID/NetworkID or ClusterID_NetworkID",
+			importID,
+		)
+	}
+	return ovirtclient.ClusterID(parts[0]), ovirtclient.NetworkID(parts[1]), nil
+}
+
 func clusterNetworkResourceUpdate(clusterNetwork ovirtclient.ClusterNetwork, data *schema.ResourceData) diag.Diagnostics {
 	if err := data.Set("cluster_id", string(clusterNetwork.ClusterID())); err != nil {
 		return diag.FromErr(err)
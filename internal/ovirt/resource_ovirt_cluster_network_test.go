@@ -0,0 +1,37 @@
+package ovirt
+
+import (
+	"testing"
+)
+
+func TestParseClusterNetworkImportID(t *testing.T) {
+	t.Parallel()
+
+	const clusterID = "3b940b57-d3a5-448e-9bb3-0d73b76fbb08"
+	const networkID = "7f7f43a8-7fc9-439e-96a0-2cb1737f9234"
+	for _, importID := range []string{
+		clusterID + "/" + networkID,
+		clusterID + "_" + networkID,
+	} {
+		parsedClusterID, parsedNetworkID, err := parseClusterNetworkImportID(importID)
+		if err != nil {
+			t.Fatalf("failed to parse import ID %s (%v)", importID, err)
+		}
+		if string(parsedClusterID) != clusterID || string(parsedNetworkID) != networkID {
+			t.Fatalf("incorrect IDs parsed from %s: %s, %s", importID, parsedClusterID, parsedNetworkID)
+		}
+	}
+
+	for _, importID := range []string{
+		"",
+		clusterID,
+		clusterID + "/",
+		"/" + networkID,
+		clusterID + "//" + networkID,
+		clusterID + "/" + networkID + "_" + networkID,
+	} {
+		if _, _, err := parseClusterNetworkImportID(importID); err == nil {
+			t.Fatalf("no error returned for malformed import ID %q", importID)
+		}
+	}
+}
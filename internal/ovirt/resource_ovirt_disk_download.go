@@ -0,0 +1,254 @@
+package ovirt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ovirtclientlog "github.com/ovirt/go-ovirt-client-log/v3"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+var diskDownloadSchema = map[string]*schema.Schema{
+	"id": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	"disk_id": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "ID of the disk to download.",
+		ValidateDiagFunc: validateUUID,
+	},
+	"destination_file": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "Path of the local file to write the disk image to. The file is removed when the resource is destroyed.",
+		ValidateDiagFunc: validateNonEmpty,
+	},
+	"format": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  string(ovirtclient.ImageFormatRaw),
+		Description: fmt.Sprintf(
+			"Format to download the disk image in. One of: `%s`",
+			strings.Join(ovirtclient.ImageFormatValues().Strings(), "`, `"),
+		),
+		ValidateDiagFunc: validateFormat,
+		ForceNew:         true,
+	},
+	"compute_sha256": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		ForceNew:    true,
+		Description: "Compute the SHA-256 checksum of the downloaded image and expose it in the sha256 attribute.",
+	},
+	"size": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Size of the downloaded image in bytes.",
+	},
+	"sha256": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Hex-encoded SHA-256 checksum of the downloaded image. Only set if compute_sha256 is enabled.",
+	},
+}
+
+func (p *provider) diskDownloadResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: p.diskDownloadCreate,
+		ReadContext:   p.diskDownloadRead,
+		DeleteContext: p.diskDownloadDelete,
+		Schema:        diskDownloadSchema,
+		Description: `The ovirt_disk_download resource downloads the image of a disk in oVirt to a local file.
+
+The image is written to a temporary file next to the destination and only moved in place once the download is complete and its size has been verified. If the transfer fails because of a broken connection or a timeout, it is restarted from the beginning up to three times; resuming a partial download is not supported. If the local file is removed or changes size, the image is downloaded again on the next apply.`,
+	}
+}
+
+func (p *provider) diskDownloadCreate(ctx context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	client := p.client.WithContext(ctx)
+	diskID := data.Get("disk_id").(string)
+	format := data.Get("format").(string)
+	destinationFileName := data.Get("destination_file").(string)
+	destinationFile, err := filepath.Abs(destinationFileName)
+	if err != nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to find absolute path for %s", destinationFileName),
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	fh, err := os.CreateTemp(filepath.Dir(destinationFile), filepath.Base(destinationFile)+".*.tmp")
+	if err != nil {
+		return errorToDiags(fmt.Sprintf("create temporary file for %s", destinationFile), err)
+	}
+	tempFile := fh.Name()
+	written, checksum, err := downloadWithRetry(
+		ctx,
+		newTerraformLogger().WithContext(ctx),
+		fh,
+		data.Get("compute_sha256").(bool),
+		diskDownloadRetryDelay,
+		func() (ovirtclient.ImageDownloadReader, error) {
+			return client.DownloadDisk(ovirtclient.DiskID(diskID), ovirtclient.ImageFormat(format))
+		},
+	)
+	if closeErr := fh.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempFile, destinationFile)
+	}
+	if err != nil {
+		_ = os.Remove(tempFile)
+		return errorToDiags(fmt.Sprintf("download disk %s to %s", diskID, destinationFile), err)
+	}
+
+	diags := diag.Diagnostics{}
+	data.SetId(destinationFile)
+	diags = setResourceField(data, "size", int(written), diags)
+	diags = setResourceField(data, "sha256", checksum, diags)
+	return diags
+}
+
+// diskDownloadMaxAttempts is the number of times a disk download is attempted before giving up.
+const diskDownloadMaxAttempts = 3
+
+// diskDownloadRetryDelay is the time to wait before restarting a failed disk download.
+const diskDownloadRetryDelay = 10 * time.Second
+
+// downloadWithRetry downloads an image into the target file. If the transfer fails with a transient error, the file is
+// truncated and the transfer is restarted from the beginning up to diskDownloadMaxAttempts times.
+func downloadWithRetry(
+	ctx context.Context,
+	logger ovirtclientlog.Logger,
+	target *os.File,
+	computeChecksum bool,
+	retryDelay time.Duration,
+	startDownload func() (ovirtclient.ImageDownloadReader, error),
+) (int64, string, error) {
+	for attempt := 1; ; attempt++ {
+		written, checksum, err := downloadToFile(target, computeChecksum, startDownload)
+		if err == nil || attempt >= diskDownloadMaxAttempts || !isTransientTransferError(err) {
+			return written, checksum, err
+		}
+		logger.Warningf(
+			"Disk image download failed after %d bytes, restarting it (attempt %d of %d)... (%v)",
+			written,
+			attempt+1,
+			diskDownloadMaxAttempts,
+			err,
+		)
+		select {
+		case <-ctx.Done():
+			return written, checksum, err
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// downloadToFile truncates the target file and downloads the image into it.
+func downloadToFile(
+	target *os.File,
+	computeChecksum bool,
+	startDownload func() (ovirtclient.ImageDownloadReader, error),
+) (int64, string, error) {
+	if err := target.Truncate(0); err != nil {
+		return 0, "", err
+	}
+	if _, err := target.Seek(0, io.SeekStart); err != nil {
+		return 0, "", err
+	}
+	download, err := startDownload()
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		_ = download.Close()
+	}()
+	written, checksum, err := copyWithSHA256(target, download, computeChecksum)
+	if err == nil && download.Size() != 0 && uint64(written) != download.Size() {
+		err = fmt.Errorf(
+			"downloaded %d bytes, but the disk image has %d bytes (%w)",
+			written,
+			download.Size(),
+			io.ErrUnexpectedEOF,
+		)
+	}
+	return written, checksum, err
+}
+
+// isTransientTransferError returns true if the error is caused by a broken or timed out connection and the transfer
+// can be restarted.
+func isTransientTransferError(err error) bool {
+	var engineErr ovirtclient.EngineError
+	if errors.As(err, &engineErr) {
+		return engineErr.HasCode(ovirtclient.EConnection) || engineErr.HasCode(ovirtclient.ETimeout)
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// copyWithSHA256 copies the data from the reader to the target and returns the number of bytes written. If
+// computeChecksum is set, it also returns the hex-encoded SHA-256 of the data.
+func copyWithSHA256(target io.Writer, source io.Reader, computeChecksum bool) (int64, string, error) {
+	var hasher hash.Hash
+	if computeChecksum {
+		hasher = sha256.New()
+		target = io.MultiWriter(target, hasher)
+	}
+	written, err := io.Copy(target, source)
+	if err != nil {
+		return written, "", err
+	}
+	if hasher == nil {
+		return written, "", nil
+	}
+	return written, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func (p *provider) diskDownloadRead(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	stat, err := os.Stat(data.Id())
+	if err != nil {
+		if os.IsNotExist(err) {
+			data.SetId("")
+			return nil
+		}
+		return errorToDiags(fmt.Sprintf("stat file %s", data.Id()), err)
+	}
+	if stat.Size() != int64(data.Get("size").(int)) {
+		data.SetId("")
+	}
+	return nil
+}
+
+func (p *provider) diskDownloadDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	if err := os.Remove(data.Id()); err != nil && !os.IsNotExist(err) {
+		return errorToDiags(fmt.Sprintf("remove file %s", data.Id()), err)
+	}
+	data.SetId("")
+	return nil
+}
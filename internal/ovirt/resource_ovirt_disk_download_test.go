@@ -0,0 +1,203 @@
+package ovirt
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestDiskDownload(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	storageDomainID := p.getTestHelper().GetStorageDomainID()
+	image, err := os.ReadFile("./testimage/image")
+	if err != nil {
+		t.Fatalf("Failed to read test image (%v)", err)
+	}
+	checksum := sha256.Sum256(image)
+	destinationFile := filepath.Join(t.TempDir(), "image.raw")
+
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(
+						`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_disk_from_image" "source" {
+	storage_domain_id = "%s"
+	format            = "raw"
+	alias             = "test"
+	sparse            = true
+	source_file       = "./testimage/image"
+}
+
+resource "ovirt_disk_download" "test" {
+	disk_id          = ovirt_disk_from_image.source.id
+	destination_file = "%s"
+	compute_sha256   = true
+}
+`,
+						storageDomainID,
+						destinationFile,
+					),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("ovirt_disk_download.test", "size", fmt.Sprintf("%d", len(image))),
+						resource.TestCheckResourceAttr(
+							"ovirt_disk_download.test", "sha256", hex.EncodeToString(checksum[:]),
+						),
+						func(_ *terraform.State) error {
+							downloaded, err := os.ReadFile(destinationFile)
+							if err != nil {
+								return err
+							}
+							if !bytes.Equal(downloaded, image) {
+								return fmt.Errorf("the downloaded image does not match the uploaded image")
+							}
+							return nil
+						},
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestCopyWithSHA256(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("Hello world!")
+	expectedChecksum := sha256.Sum256(data)
+
+	target := &bytes.Buffer{}
+	written, checksum, err := copyWithSHA256(target, bytes.NewReader(data), true)
+	if err != nil {
+		t.Fatalf("failed to copy data (%v)", err)
+	}
+	if written != int64(len(data)) || !bytes.Equal(target.Bytes(), data) {
+		t.Fatalf("incorrect data copied: %d bytes, %q", written, target.String())
+	}
+	if checksum != hex.EncodeToString(expectedChecksum[:]) {
+		t.Fatalf("incorrect checksum: %s", checksum)
+	}
+
+	target.Reset()
+	if _, checksum, err = copyWithSHA256(target, bytes.NewReader(data), false); err != nil {
+		t.Fatalf("failed to copy data (%v)", err)
+	}
+	if checksum != "" {
+		t.Fatalf("checksum computed even though it was not requested: %s", checksum)
+	}
+}
+
+func TestDownloadWithRetry(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("Hello world!")
+	target, err := os.Create(filepath.Join(t.TempDir(), "image.raw"))
+	if err != nil {
+		t.Fatalf("failed to create target file (%v)", err)
+	}
+	defer func() {
+		_ = target.Close()
+	}()
+
+	attempts := 0
+	written, _, err := downloadWithRetry(
+		context.Background(),
+		newTestLogger(t).WithContext(context.Background()),
+		target,
+		false,
+		0,
+		func() (ovirtclient.ImageDownloadReader, error) {
+			attempts++
+			if attempts == 1 {
+				// The first transfer breaks after writing part of the image.
+				return &testImageDownload{data: data, failAfter: 5}, nil
+			}
+			return &testImageDownload{data: data, failAfter: -1}, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("download failed (%v)", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("incorrect number of download attempts: %d", attempts)
+	}
+	downloaded, err := os.ReadFile(target.Name())
+	if err != nil {
+		t.Fatalf("failed to read downloaded file (%v)", err)
+	}
+	if written != int64(len(data)) || !bytes.Equal(downloaded, data) {
+		t.Fatalf("incorrect data downloaded: %d bytes, %q", written, downloaded)
+	}
+
+	attempts = 0
+	expectedErr := fmt.Errorf("permission denied")
+	_, _, err = downloadWithRetry(
+		context.Background(),
+		newTestLogger(t).WithContext(context.Background()),
+		target,
+		false,
+		0,
+		func() (ovirtclient.ImageDownloadReader, error) {
+			attempts++
+			return nil, expectedErr
+		},
+	)
+	if !errors.Is(err, expectedErr) || attempts != 1 {
+		t.Fatalf("permanent error was retried or not returned: %d attempts (%v)", attempts, err)
+	}
+}
+
+// testImageDownload serves data and fails with io.ErrUnexpectedEOF after failAfter bytes. A negative failAfter serves
+// the whole data.
+type testImageDownload struct {
+	data      []byte
+	failAfter int
+	bytesRead int
+}
+
+func (d *testImageDownload) Read(p []byte) (int, error) {
+	if d.failAfter >= 0 && d.bytesRead >= d.failAfter {
+		return 0, io.ErrUnexpectedEOF
+	}
+	end := len(d.data)
+	if d.failAfter >= 0 && d.failAfter < end {
+		end = d.failAfter
+	}
+	if d.bytesRead >= end {
+		return 0, io.EOF
+	}
+	n := copy(p, d.data[d.bytesRead:end])
+	d.bytesRead += n
+	return n, nil
+}
+
+func (d *testImageDownload) Close() error {
+	return nil
+}
+
+func (d *testImageDownload) BytesRead() uint64 {
+	return uint64(d.bytesRead)
+}
+
+func (d *testImageDownload) Size() uint64 {
+	return uint64(len(d.data))
+}
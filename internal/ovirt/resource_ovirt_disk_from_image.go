@@ -1,41 +1,104 @@
 package ovirt
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/klauspost/compress/zstd"
+	ovirtclientlog "github.com/ovirt/go-ovirt-client-log/v3"
+	"github.com/ulikunitz/xz"
 	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
 )
 
 var diskFromImageSchema = schemaMerge(
 	diskBaseSchema, map[string]*schema.Schema{
+		"format": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"Format for the disk. One of: `%s`. If not set, qcow2 images are uploaded as `cow` and all other images as `raw`.",
+				strings.Join(ovirtclient.ImageFormatValues().Strings(), "`, `"),
+			),
+			ValidateDiagFunc: validateFormat,
+			ForceNew:         true,
+		},
 		"source_file": {
 			Type:             schema.TypeString,
-			Required:         true,
+			Optional:         true,
 			ForceNew:         true,
-			Description:      "Path to the local file to upload as the disk image.",
+			ExactlyOneOf:     []string{"source_file", "source_url"},
+			Description:      "Path to the local file to upload as the disk image. Gzip-, xz- and zstd-compressed images are decompressed before the upload.",
 			ValidateDiagFunc: validateLocalFile,
 		},
+		"source_url": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ExactlyOneOf:     []string{"source_file", "source_url"},
+			Description:      "HTTP or HTTPS URL to download the disk image from. The image is downloaded to a temporary file before the upload. Gzip-, xz- and zstd-compressed images are decompressed before the upload.",
+			ValidateDiagFunc: validateHTTPURL,
+		},
+		"source_url_sha256": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			RequiredWith:     []string{"source_url"},
+			Description:      "Expected hex-encoded SHA-256 checksum of the file downloaded from source_url, before decompression. The upload is aborted if the checksum does not match.",
+			ValidateDiagFunc: validateSHA256,
+		},
 		"size": {
 			Type:        schema.TypeInt,
 			Computed:    true,
 			Description: "Disk size in bytes.",
 		},
+		"keep_on_failure": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "Keep the disk if the image upload fails instead of removing it. The disk is then tracked as " +
+				"tainted and replaced on the next apply. This is mainly useful for debugging failed uploads.",
+		},
+		"source_checksum": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "Hex-encoded SHA-256 checksum of the source image, before decompression. For source_file, it is " +
+				"recomputed when the size or modification time of the file changes, and a changed file is uploaded " +
+				"into the existing disk in place. The new image must fit into the disk, and the disk must not be in " +
+				"use by a running VM.",
+		},
+		"source_file_size": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Size of source_file in bytes when source_checksum was last computed.",
+		},
+		"source_file_modified": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Modification time of source_file in RFC 3339 format when source_checksum was last computed.",
+		},
 	},
 )
 
 func (p *provider) diskFromImageResource() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: p.diskFromImageCreate,
-		ReadContext:   p.diskRead,
-		UpdateContext: p.diskUpdate,
+		ReadContext:   p.diskFromImageRead,
+		UpdateContext: p.diskFromImageUpdate,
 		DeleteContext: p.diskDelete,
+		CustomizeDiff: diskFromImageCustomizeDiff,
 		Schema:        diskFromImageSchema,
-		Description:   "The ovirt_disk_from_image resource creates disks in oVirt from a local image file.",
+		Description:   "The ovirt_disk_from_image resource creates disks in oVirt from a local image file or a URL.",
 	}
 }
 
@@ -71,57 +134,470 @@ func (p *provider) diskFromImageCreate(ctx context.Context, data *schema.Resourc
 			}
 		}
 	}
-	sourceFileName := data.Get("source_file").(string)
-	sourceFile, err := filepath.Abs(sourceFileName)
-	if err != nil {
-		return diag.Diagnostics{
-			diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Failed to find absolute path for %s", sourceFileName),
-				Detail:   err.Error(),
-			},
-		}
+	sourceFile, checksum, cleanup, sourceDiags := diskFromImageSource(ctx, data)
+	defer cleanup()
+	if sourceDiags.HasError() {
+		return sourceDiags
 	}
 	// We actually want to include the file here, so this is not gosec-relevant.
 	fh, err := os.Open(sourceFile) //nolint:gosec
 	if err != nil {
 		return errorToDiags(fmt.Sprintf("opening file %s", sourceFile), err)
 	}
+	defer func() {
+		_ = fh.Close()
+	}()
 	stat, err := fh.Stat()
 	if err != nil {
 		return errorToDiags(fmt.Sprintf("opening file %s", sourceFile), err)
 	}
-	upload, err := client.UploadToNewDisk(
+	detectedFormat, virtualSize, err := detectImageParameters(fh, uint64(stat.Size()))
+	if err != nil {
+		return errorToDiags(fmt.Sprintf("reading image header of %s", sourceFile), err)
+	}
+	if format == "" {
+		format = string(detectedFormat)
+	}
+	params, err = params.WithInitialSize(uint64(stat.Size()))
+	if err != nil {
+		return errorToDiags("set initial disk size", err)
+	}
+
+	disk, err := client.CreateDisk(
 		ovirtclient.StorageDomainID(storageDomainID),
 		ovirtclient.ImageFormat(format),
-		uint64(stat.Size()),
+		virtualSize,
 		params,
-		fh,
 	)
-	var disk ovirtclient.Disk
-	if upload != nil {
-		disk = upload.Disk()
+	if err != nil {
+		return errorToDiags("create disk", err)
+	}
+	upload, err := client.StartUploadToDisk(disk.ID(), uint64(stat.Size()), fh)
+	if err == nil {
+		err = waitForImageUpload(
+			newTerraformLogger().WithContext(ctx),
+			upload,
+			diskUploadProgressInterval,
+		)
 	}
 	if err != nil {
 		diags := diag.Diagnostics{
 			diag.Diagnostic{
 				Severity: diag.Error,
-				Summary:  "Failed to create disk.",
+				Summary:  "Failed to upload disk image.",
 				Detail:   err.Error(),
 			},
 		}
-		if disk != nil {
-			if err := disk.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
-				diags = append(
-					diags, diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  fmt.Sprintf("Failed to remove created disk %s", disk.ID()),
-						Detail:   err.Error(),
-					},
-				)
+		if data.Get("keep_on_failure").(bool) {
+			data.SetId(string(disk.ID()))
+			return append(
+				diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Kept disk %s after failed upload", disk.ID()),
+					Detail:   "keep_on_failure is set, the disk will be replaced on the next apply.",
+				},
+			)
+		}
+		if err := disk.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			diags = append(
+				diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Failed to remove created disk %s", disk.ID()),
+					Detail:   err.Error(),
+				},
+			)
+		}
+		return diags
+	}
+	disk, err = client.GetDisk(disk.ID())
+	if err != nil {
+		return errorToDiags("get uploaded disk", err)
+	}
+	diags := diskResourceUpdate(disk, data)
+	return setResourceField(data, "source_checksum", checksum, diags)
+}
+
+func (p *provider) diskFromImageRead(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
+	diags := p.diskRead(ctx, data, i)
+	if diags.HasError() || data.Id() == "" {
+		return diags
+	}
+	sourceFileName, ok := data.GetOk("source_file")
+	if !ok {
+		return diags
+	}
+	if data.Get("source_checksum").(string) == "" {
+		// Resources created before source_checksum existed record the current file as the baseline instead of
+		// uploading it again.
+		size, modified, err := imageFileStat(sourceFileName.(string))
+		if err != nil {
+			return append(diags, errorToDiags(fmt.Sprintf("reading file information of %s", sourceFileName), err)...)
+		}
+		checksum, err := fileSHA256(sourceFileName.(string))
+		if err != nil {
+			return append(diags, errorToDiags(fmt.Sprintf("computing checksum of %s", sourceFileName), err)...)
+		}
+		diags = setResourceField(data, "source_file_size", size, diags)
+		diags = setResourceField(data, "source_file_modified", modified, diags)
+		return setResourceField(data, "source_checksum", checksum, diags)
+	}
+	// If the file was touched without changing its content, record the new size and modification time so the
+	// checksum is not recomputed on every plan. Errors are left to diskFromImageCustomizeDiff to report.
+	size, modified, err := imageFileStat(sourceFileName.(string))
+	if err != nil ||
+		(size == data.Get("source_file_size").(int) && modified == data.Get("source_file_modified").(string)) {
+		return diags
+	}
+	checksum, err := fileSHA256(sourceFileName.(string))
+	if err != nil || checksum != data.Get("source_checksum").(string) {
+		return diags
+	}
+	diags = setResourceField(data, "source_file_size", size, diags)
+	return setResourceField(data, "source_file_modified", modified, diags)
+}
+
+func (p *provider) diskFromImageUpdate(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
+	if data.HasChange("source_checksum") {
+		if diags := p.diskFromImageUpload(ctx, data); diags.HasError() {
+			// Keep the previous source file information so the upload is attempted again on the next apply.
+			for _, field := range []string{"source_checksum", "source_file_size", "source_file_modified"} {
+				oldValue, _ := data.GetChange(field)
+				diags = setResourceField(data, field, oldValue, diags)
 			}
+			return diags
 		}
+	}
+	return p.diskUpdate(ctx, data, i)
+}
+
+// diskFromImageUpload uploads the current source image into the existing disk.
+func (p *provider) diskFromImageUpload(ctx context.Context, data *schema.ResourceData) diag.Diagnostics {
+	client := p.client.WithContext(ctx)
+	sourceFile, checksum, cleanup, diags := diskFromImageSource(ctx, data)
+	defer cleanup()
+	if diags.HasError() {
 		return diags
 	}
-	return diskResourceUpdate(disk, data)
+	// We actually want to include the file here, so this is not gosec-relevant.
+	fh, err := os.Open(sourceFile) //nolint:gosec
+	if err != nil {
+		return errorToDiags(fmt.Sprintf("opening file %s", sourceFile), err)
+	}
+	defer func() {
+		_ = fh.Close()
+	}()
+	stat, err := fh.Stat()
+	if err != nil {
+		return errorToDiags(fmt.Sprintf("opening file %s", sourceFile), err)
+	}
+	upload, err := client.StartUploadToDisk(ovirtclient.DiskID(data.Id()), uint64(stat.Size()), fh)
+	if err == nil {
+		err = waitForImageUpload(newTerraformLogger().WithContext(ctx), upload, diskUploadProgressInterval)
+	}
+	if err != nil {
+		return errorToDiags(fmt.Sprintf("uploading image to disk %s", data.Id()), err)
+	}
+	return setResourceField(data, "source_checksum", checksum, diags)
+}
+
+// diskFromImageCustomizeDiff recomputes the checksum of source_file so that a changed file results in an in-place
+// upload. To avoid reading large images on every plan, the checksum is only recomputed if the size or modification
+// time of the file changed, and the new size and modification time are only planned if the content changed as well.
+// For new resources the checksum is computed during the upload.
+func diskFromImageCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || diff.HasChange("source_file") || !diff.NewValueKnown("source_file") {
+		return nil
+	}
+	sourceFileName, ok := diff.GetOk("source_file")
+	if !ok {
+		return nil
+	}
+	size, modified, err := imageFileStat(sourceFileName.(string))
+	if err != nil {
+		return fmt.Errorf("failed to read file information of %s (%w)", sourceFileName, err)
+	}
+	if size == diff.Get("source_file_size").(int) && modified == diff.Get("source_file_modified").(string) {
+		return nil
+	}
+	checksum, err := fileSHA256(sourceFileName.(string))
+	if err != nil {
+		return fmt.Errorf("failed to compute checksum of %s (%w)", sourceFileName, err)
+	}
+	if checksum == diff.Get("source_checksum").(string) {
+		return nil
+	}
+	if err := diff.SetNew("source_file_size", size); err != nil {
+		return err
+	}
+	if err := diff.SetNew("source_file_modified", modified); err != nil {
+		return err
+	}
+	return diff.SetNew("source_checksum", checksum)
+}
+
+// imageFileStat returns the size and the modification time in RFC 3339 format of a file.
+func imageFileStat(fileName string) (int, string, error) {
+	stat, err := os.Stat(fileName)
+	if err != nil {
+		return 0, "", err
+	}
+	return int(stat.Size()), stat.ModTime().UTC().Format(time.RFC3339Nano), nil
+}
+
+func fileSHA256(fileName string) (string, error) {
+	// We actually want to include the file here, so this is not gosec-relevant.
+	fh, err := os.Open(fileName) //nolint:gosec
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = fh.Close()
+	}()
+	_, checksum, err := copyWithSHA256(io.Discard, fh, true)
+	return checksum, err
+}
+
+// diskFromImageSource returns the path of an uncompressed local image to upload and the SHA-256 checksum of the
+// source before decompression. Images from source_url are downloaded to a temporary file first, and compressed
+// images are decompressed into a temporary file. For source_file, the size and modification time of the file are
+// recorded in data before it is hashed. The returned cleanup function removes all temporary files and must always be
+// called.
+func diskFromImageSource(ctx context.Context, data *schema.ResourceData) (string, string, func(), diag.Diagnostics) {
+	var tempFiles []string
+	cleanup := func() {
+		for _, tempFile := range tempFiles {
+			_ = os.Remove(tempFile)
+		}
+	}
+
+	var sourceFile string
+	var checksum string
+	if sourceURL, ok := data.GetOk("source_url"); ok {
+		downloadedFile, downloadedChecksum, err := downloadImageSource(
+			ctx,
+			sourceURL.(string),
+			data.Get("source_url_sha256").(string),
+		)
+		if err != nil {
+			return "", "", cleanup, errorToDiags(fmt.Sprintf("downloading image from %s", sourceURL), err)
+		}
+		tempFiles = append(tempFiles, downloadedFile)
+		sourceFile = downloadedFile
+		checksum = downloadedChecksum
+	} else {
+		sourceFileName := data.Get("source_file").(string)
+		var err error
+		sourceFile, err = filepath.Abs(sourceFileName)
+		if err != nil {
+			return "", "", cleanup, diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Failed to find absolute path for %s", sourceFileName),
+					Detail:   err.Error(),
+				},
+			}
+		}
+		size, modified, err := imageFileStat(sourceFile)
+		if err != nil {
+			return "", "", cleanup, errorToDiags(fmt.Sprintf("reading file information of %s", sourceFile), err)
+		}
+		checksum, err = fileSHA256(sourceFile)
+		if err != nil {
+			return "", "", cleanup, errorToDiags(fmt.Sprintf("computing checksum of %s", sourceFile), err)
+		}
+		diags := setResourceField(data, "source_file_size", size, nil)
+		diags = setResourceField(data, "source_file_modified", modified, diags)
+		if diags.HasError() {
+			return "", "", cleanup, diags
+		}
+	}
+
+	decompressedFile, err := decompressImageSource(sourceFile)
+	if err != nil {
+		return "", "", cleanup, errorToDiags(fmt.Sprintf("decompressing image %s", sourceFile), err)
+	}
+	if decompressedFile != "" {
+		tempFiles = append(tempFiles, decompressedFile)
+		sourceFile = decompressedFile
+	}
+	return sourceFile, checksum, cleanup, nil
+}
+
+// downloadImageSource downloads the image from the specified URL into a temporary file and returns its path and
+// SHA-256 checksum. If expectedSHA256 is not empty, the checksum of the downloaded file is verified against it.
+func downloadImageSource(ctx context.Context, sourceURL string, expectedSHA256 string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	fh, err := os.CreateTemp("", "ovirt-image-*")
+	if err != nil {
+		return "", "", err
+	}
+	_, checksum, err := copyWithSHA256(fh, resp.Body, true)
+	if closeErr := fh.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && expectedSHA256 != "" && !strings.EqualFold(checksum, expectedSHA256) {
+		err = fmt.Errorf("checksum mismatch, expected SHA-256 %s, got %s", expectedSHA256, checksum)
+	}
+	if err != nil {
+		_ = os.Remove(fh.Name())
+		return "", "", err
+	}
+	return fh.Name(), checksum, nil
+}
+
+var (
+	gzipMagicBytes = []byte{0x1f, 0x8b}
+	xzMagicBytes   = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+	zstdMagicBytes = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	qcowMagicBytes = []byte{'Q', 'F', 'I', 0xfb}
+)
+
+// decompressImageSource decompresses a gzip-, xz- or zstd-compressed image into a temporary file and returns its
+// path. If the image is not compressed, it returns an empty string.
+func decompressImageSource(sourceFile string) (string, error) {
+	// We actually want to include the file here, so this is not gosec-relevant.
+	fh, err := os.Open(sourceFile) //nolint:gosec
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = fh.Close()
+	}()
+	header, err := readImageHeader(fh, len(xzMagicBytes))
+	if err != nil {
+		return "", err
+	}
+	var decompressed io.Reader
+	switch {
+	case hasMagicBytes(header, gzipMagicBytes):
+		gz, err := gzip.NewReader(fh)
+		if err != nil {
+			return "", err
+		}
+		defer func() {
+			_ = gz.Close()
+		}()
+		decompressed = gz
+	case hasMagicBytes(header, xzMagicBytes):
+		xzReader, err := xz.NewReader(fh)
+		if err != nil {
+			return "", err
+		}
+		decompressed = xzReader
+	case hasMagicBytes(header, zstdMagicBytes):
+		zstdReader, err := zstd.NewReader(fh)
+		if err != nil {
+			return "", err
+		}
+		defer zstdReader.Close()
+		decompressed = zstdReader
+	default:
+		return "", nil
+	}
+
+	target, err := os.CreateTemp("", "ovirt-image-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(target, decompressed)
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(target.Name())
+		return "", err
+	}
+	return target.Name(), nil
+}
+
+// diskUploadProgressInterval is the interval in which the progress of image uploads is logged.
+const diskUploadProgressInterval = 30 * time.Second
+
+// minimumDiskSize is the smallest disk oVirt creates for an uploaded image.
+const minimumDiskSize = 1024 * 1024
+
+// qcowSizeOffset is the offset of the virtual disk size in the qcow2 header.
+const qcowSizeOffset = 24
+
+// detectImageParameters returns the format and virtual size of an image. qcow2 images are detected as cow with the
+// virtual size from their header, all other images as raw with the file size. The file is rewound afterwards.
+func detectImageParameters(fh *os.File, fileSize uint64) (ovirtclient.ImageFormat, uint64, error) {
+	header, err := readImageHeader(fh, qcowSizeOffset+8)
+	if err != nil {
+		return "", 0, err
+	}
+	format := ovirtclient.ImageFormatRaw
+	size := fileSize
+	if hasMagicBytes(header, qcowMagicBytes) {
+		if len(header) < qcowSizeOffset+8 {
+			return "", 0, fmt.Errorf("truncated qcow2 header")
+		}
+		format = ovirtclient.ImageFormatCow
+		size = binary.BigEndian.Uint64(header[qcowSizeOffset : qcowSizeOffset+8])
+	}
+	if size < minimumDiskSize {
+		size = minimumDiskSize
+	}
+	return format, size, nil
+}
+
+// waitForImageUpload waits for the upload to finish and logs its progress in the specified interval.
+func waitForImageUpload(
+	logger ovirtclientlog.Logger,
+	upload ovirtclient.UploadImageProgress,
+	interval time.Duration,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-upload.Done():
+			return upload.Err()
+		case <-ticker.C:
+			uploaded := upload.UploadedBytes()
+			total := upload.TotalBytes()
+			if total == 0 {
+				logger.Infof("Uploaded %d bytes of disk image...", uploaded)
+				continue
+			}
+			logger.Infof(
+				"Uploaded %d of %d bytes of disk image (%d%%)...",
+				uploaded,
+				total,
+				uploaded*100/total,
+			)
+		}
+	}
+}
+
+// readImageHeader reads up to length bytes from the start of the file and rewinds it. Files shorter than length
+// return a shorter header.
+func readImageHeader(fh *os.File, length int) ([]byte, error) {
+	header := make([]byte, length)
+	n, err := io.ReadFull(fh, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	if _, err := fh.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return header[:n], nil
+}
+
+func hasMagicBytes(header []byte, magic []byte) bool {
+	return len(header) >= len(magic) && string(header[:len(magic)]) == string(magic)
 }
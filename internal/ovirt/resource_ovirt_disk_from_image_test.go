@@ -1,11 +1,29 @@
 package ovirt
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/klauspost/compress/zstd"
+	ovirtclientlog "github.com/ovirt/go-ovirt-client-log/v3"
+	"github.com/ulikunitz/xz"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
 )
 
 func TestImageUpload(t *testing.T) {
@@ -52,3 +70,586 @@ resource "ovirt_disk_from_image" "foo" {
 		},
 	)
 }
+
+func TestImageUploadCompressedFromURL(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	storageDomainID := p.getTestHelper().GetStorageDomainID()
+	compressedImage := gzipTestImage(t)
+	compressedFile := filepath.Join(t.TempDir(), "image.gz")
+	if err := os.WriteFile(compressedFile, compressedImage, 0o600); err != nil {
+		t.Fatalf("Failed to write compressed image (%v)", err)
+	}
+	checksum := sha256.Sum256(compressedImage)
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(compressedImage)
+		}),
+	)
+	t.Cleanup(server.Close)
+
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(
+						`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_disk_from_image" "file" {
+	storage_domain_id = "%s"
+	alias             = "test-file"
+	sparse            = true
+	source_file       = "%s"
+}
+
+resource "ovirt_disk_from_image" "url" {
+	storage_domain_id = "%s"
+	alias             = "test-url"
+	sparse            = true
+	source_url        = "%s/image.gz"
+	source_url_sha256 = "%s"
+}
+`,
+						storageDomainID,
+						compressedFile,
+						storageDomainID,
+						server.URL,
+						hex.EncodeToString(checksum[:]),
+					),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("ovirt_disk_from_image.file", "format", "raw"),
+						resource.TestCheckResourceAttr("ovirt_disk_from_image.file", "size", fmt.Sprintf("%d", 1024*1024)),
+						resource.TestCheckResourceAttr("ovirt_disk_from_image.url", "format", "raw"),
+						resource.TestCheckResourceAttr("ovirt_disk_from_image.url", "size", fmt.Sprintf("%d", 1024*1024)),
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestImageUploadInPlaceOnSourceChange(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	storageDomainID := p.getTestHelper().GetStorageDomainID()
+	image, err := os.ReadFile("./testimage/image")
+	if err != nil {
+		t.Fatalf("Failed to read test image (%v)", err)
+	}
+	sourceFile := filepath.Join(t.TempDir(), "image")
+	if err := os.WriteFile(sourceFile, image, 0o600); err != nil {
+		t.Fatalf("Failed to write source image (%v)", err)
+	}
+	changedImage := append([]byte{}, image...)
+	changedImage[len(changedImage)-1] ^= 0xff
+	checksum := sha256.Sum256(image)
+	changedChecksum := sha256.Sum256(changedImage)
+
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_disk_from_image" "test" {
+	storage_domain_id = "%s"
+	format            = "raw"
+	alias             = "test"
+	sparse            = true
+	source_file       = "%s"
+}
+`,
+		storageDomainID,
+		sourceFile,
+	)
+
+	var diskID string
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(
+							"ovirt_disk_from_image.test", "source_checksum", hex.EncodeToString(checksum[:]),
+						),
+						func(state *terraform.State) error {
+							diskID = state.RootModule().Resources["ovirt_disk_from_image.test"].Primary.ID
+							return nil
+						},
+					),
+				},
+				{
+					// Touching the file without changing its content must not result in a change.
+					PreConfig: func() {
+						modified := time.Now().Add(time.Minute)
+						if err := os.Chtimes(sourceFile, modified, modified); err != nil {
+							t.Fatalf("Failed to change modification time of source image (%v)", err)
+						}
+					},
+					Config:   config,
+					PlanOnly: true,
+				},
+				{
+					PreConfig: func() {
+						if err := os.WriteFile(sourceFile, changedImage, 0o600); err != nil {
+							t.Fatalf("Failed to write changed source image (%v)", err)
+						}
+						// Make sure the modification time changes even on file systems with a coarse resolution.
+						modified := time.Now().Add(2 * time.Minute)
+						if err := os.Chtimes(sourceFile, modified, modified); err != nil {
+							t.Fatalf("Failed to change modification time of source image (%v)", err)
+						}
+					},
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(
+							"ovirt_disk_from_image.test", "source_checksum", hex.EncodeToString(changedChecksum[:]),
+						),
+						func(state *terraform.State) error {
+							id := state.RootModule().Resources["ovirt_disk_from_image.test"].Primary.ID
+							if id != diskID {
+								return fmt.Errorf("disk was recreated instead of updated (%s != %s)", id, diskID)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestDiskFromImageCustomizeDiffSkipsUnchangedFile(t *testing.T) {
+	t.Parallel()
+
+	sourceFile := filepath.Join(t.TempDir(), "image")
+	if err := os.WriteFile(sourceFile, []byte("Hello world!"), 0o600); err != nil {
+		t.Fatalf("Failed to write source image (%v)", err)
+	}
+	size, modified, err := imageFileStat(sourceFile)
+	if err != nil {
+		t.Fatalf("Failed to read file information (%v)", err)
+	}
+	checksum, err := fileSHA256(sourceFile)
+	if err != nil {
+		t.Fatalf("Failed to compute checksum (%v)", err)
+	}
+
+	p := newProvider(newTestLogger(t)).(*provider)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"storage_domain_id": "00000000-0000-0000-0000-000000000000",
+		"format":            "raw",
+		"alias":             "test",
+		"sparse":            true,
+		"source_file":       sourceFile,
+	})
+	for name, testCase := range map[string]struct {
+		checksum         string
+		modified         string
+		expectedChecksum string
+	}{
+		// The stale checksum is kept since the file is not read again if its size and modification time match.
+		"unchanged": {"stale", modified, ""},
+		// A file with a new modification time but the same content does not result in a change.
+		"touched":  {checksum, "2006-01-02T15:04:05Z", ""},
+		"modified": {"stale", "2006-01-02T15:04:05Z", checksum},
+	} {
+		state := &terraform.InstanceState{
+			ID: "00000000-0000-0000-0000-000000000001",
+			Attributes: map[string]string{
+				"id":                   "00000000-0000-0000-0000-000000000001",
+				"storage_domain_id":    "00000000-0000-0000-0000-000000000000",
+				"format":               "raw",
+				"alias":                "test",
+				"sparse":               "true",
+				"source_file":          sourceFile,
+				"keep_on_failure":      "false",
+				"source_checksum":      testCase.checksum,
+				"source_file_size":     fmt.Sprintf("%d", size),
+				"source_file_modified": testCase.modified,
+			},
+		}
+		diff, err := p.diskFromImageResource().Diff(context.Background(), state, config, p)
+		if err != nil {
+			t.Fatalf("%s: failed to compute diff (%v)", name, err)
+		}
+		if testCase.expectedChecksum == "" {
+			if diff != nil && !diff.Empty() {
+				t.Fatalf("%s: unexpected diff: %v", name, diff.Attributes)
+			}
+			continue
+		}
+		if diff == nil {
+			t.Fatalf("%s: no diff", name)
+		}
+		for field, expected := range map[string]string{
+			"source_checksum":      testCase.expectedChecksum,
+			"source_file_modified": modified,
+		} {
+			attrDiff, ok := diff.Attributes[field]
+			if !ok || attrDiff.New != expected {
+				t.Fatalf("%s: incorrect %s in diff: %v, expected: %q", name, field, attrDiff, expected)
+			}
+		}
+	}
+}
+
+func TestDiskFromImageReadRecordsTouchedFile(t *testing.T) {
+	t.Parallel()
+
+	sourceFile := filepath.Join(t.TempDir(), "image")
+	if err := os.WriteFile(sourceFile, []byte("Hello world!"), 0o600); err != nil {
+		t.Fatalf("Failed to write source image (%v)", err)
+	}
+	size, modified, err := imageFileStat(sourceFile)
+	if err != nil {
+		t.Fatalf("Failed to read file information (%v)", err)
+	}
+	checksum, err := fileSHA256(sourceFile)
+	if err != nil {
+		t.Fatalf("Failed to compute checksum (%v)", err)
+	}
+
+	// Special case: we are using the ovirtclientlog.NewTestLogger here because we call the client methods outside of
+	// the Terraform context.
+	helper, err := ovirtclient.NewMockTestHelper(ovirtclientlog.NewTestLogger(t))
+	if err != nil {
+		t.Fatalf("failed to create mock test helper (%v)", err)
+	}
+	client := helper.GetClient()
+	disk, err := client.CreateDisk(
+		helper.GetStorageDomainID(), ovirtclient.ImageFormatRaw, 1048576,
+		ovirtclient.CreateDiskParams().MustWithAlias("test").MustWithSparse(true))
+	if err != nil {
+		t.Fatalf("failed to create disk (%v)", err)
+	}
+	p := &provider{testHelper: helper, client: client}
+
+	for name, testCase := range map[string]struct {
+		checksum         string
+		expectedModified string
+	}{
+		// The content did not change, so the new modification time is recorded.
+		"touched": {checksum, modified},
+		// The content changed, so the old modification time is kept for the plan to upload the file.
+		"modified": {"stale", "2006-01-02T15:04:05Z"},
+	} {
+		data := schema.TestResourceDataRaw(t, diskFromImageSchema, map[string]interface{}{
+			"storage_domain_id": string(helper.GetStorageDomainID()),
+			"source_file":       sourceFile,
+		})
+		data.SetId(string(disk.ID()))
+		for field, value := range map[string]interface{}{
+			"source_checksum":      testCase.checksum,
+			"source_file_size":     size,
+			"source_file_modified": "2006-01-02T15:04:05Z",
+		} {
+			if err := data.Set(field, value); err != nil {
+				t.Fatalf("%s: failed to set %s (%v)", name, field, err)
+			}
+		}
+		if diags := p.diskFromImageRead(context.Background(), data, nil); diags.HasError() {
+			t.Fatalf("%s: failed to read disk (%v)", name, diags)
+		}
+		compareResource(t, data, "source_file_modified", testCase.expectedModified)
+		compareResource(t, data, "source_checksum", testCase.checksum)
+	}
+}
+
+func TestImageUploadKeepOnFailure(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	client := p.getTestHelper().GetClient()
+	storageDomainID := p.getTestHelper().GetStorageDomainID()
+
+	// The mock backend rejects uploading a raw image into a cow disk, which makes the upload fail after the disk has
+	// been created.
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_disk_from_image" "kept" {
+	storage_domain_id = "%s"
+	format            = "cow"
+	alias             = "kept"
+	source_file       = "./testimage/image"
+	keep_on_failure   = true
+}
+
+resource "ovirt_disk_from_image" "removed" {
+	storage_domain_id = "%s"
+	format            = "cow"
+	alias             = "removed"
+	source_file       = "./testimage/image"
+}
+`,
+		storageDomainID,
+		storageDomainID,
+	)
+
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config:      config,
+					ExpectError: regexp.MustCompile("Failed to upload disk image"),
+				},
+				{
+					PreConfig: func() {
+						disks, err := client.ListDisks()
+						if err != nil {
+							t.Fatalf("Failed to list disks (%v)", err)
+						}
+						aliases := map[string]bool{}
+						for _, disk := range disks {
+							aliases[disk.Alias()] = true
+						}
+						if !aliases["kept"] {
+							t.Fatalf("the disk with keep_on_failure was removed after the failed upload")
+						}
+						if aliases["removed"] {
+							t.Fatalf("the disk without keep_on_failure was not removed after the failed upload")
+						}
+					},
+					Config:             config,
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		},
+	)
+}
+
+func TestWaitForImageUpload(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := fmt.Errorf("upload failed")
+	upload := &testImageUploadProgress{
+		done: make(chan struct{}),
+		err:  expectedErr,
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(upload.done)
+	}()
+
+	if err := waitForImageUpload(newTestLogger(t).WithContext(context.Background()), upload, time.Millisecond); err != expectedErr {
+		t.Fatalf("incorrect error returned: %v", err)
+	}
+	if atomic.LoadInt32(&upload.progressQueries) == 0 {
+		t.Fatalf("the upload progress was never logged")
+	}
+}
+
+type testImageUploadProgress struct {
+	done            chan struct{}
+	err             error
+	progressQueries int32
+}
+
+func (t *testImageUploadProgress) Disk() ovirtclient.Disk {
+	return nil
+}
+
+func (t *testImageUploadProgress) UploadedBytes() uint64 {
+	atomic.AddInt32(&t.progressQueries, 1)
+	return 512
+}
+
+func (t *testImageUploadProgress) TotalBytes() uint64 {
+	return 1024
+}
+
+func (t *testImageUploadProgress) Err() error {
+	return t.err
+}
+
+func (t *testImageUploadProgress) Done() <-chan struct{} {
+	return t.done
+}
+
+func TestDecompressImageSource(t *testing.T) {
+	t.Parallel()
+
+	image, err := os.ReadFile("./testimage/image")
+	if err != nil {
+		t.Fatalf("Failed to read test image (%v)", err)
+	}
+	dir := t.TempDir()
+	for name, compressedImage := range map[string][]byte{
+		"image.gz":  gzipTestImage(t),
+		"image.xz":  xzTestImage(t),
+		"image.zst": zstdTestImage(t),
+	} {
+		compressedFile := filepath.Join(dir, name)
+		if err := os.WriteFile(compressedFile, compressedImage, 0o600); err != nil {
+			t.Fatalf("Failed to write compressed image %s (%v)", name, err)
+		}
+
+		decompressedFile, err := decompressImageSource(compressedFile)
+		if err != nil {
+			t.Fatalf("Failed to decompress image %s (%v)", name, err)
+		}
+		t.Cleanup(func() {
+			_ = os.Remove(decompressedFile)
+		})
+		decompressed, err := os.ReadFile(decompressedFile)
+		if err != nil {
+			t.Fatalf("Failed to read decompressed image %s (%v)", name, err)
+		}
+		if !bytes.Equal(decompressed, image) {
+			t.Fatalf("the decompressed image %s does not match the original image", name)
+		}
+	}
+
+	if decompressedFile, err := decompressImageSource("./testimage/image"); err != nil || decompressedFile != "" {
+		t.Fatalf("uncompressed image was not passed through unchanged (%s, %v)", decompressedFile, err)
+	}
+
+	truncatedFile := filepath.Join(dir, "truncated.xz")
+	if err := os.WriteFile(truncatedFile, append([]byte{}, xzMagicBytes...), 0o600); err != nil {
+		t.Fatalf("Failed to write truncated image (%v)", err)
+	}
+	if _, err := decompressImageSource(truncatedFile); err == nil {
+		t.Fatalf("truncated xz-compressed image did not result in an error")
+	}
+}
+
+func TestDetectImageParameters(t *testing.T) {
+	t.Parallel()
+
+	qcowHeader := make([]byte, qcowSizeOffset+8)
+	copy(qcowHeader, qcowMagicBytes)
+	binary.BigEndian.PutUint64(qcowHeader[qcowSizeOffset:], 10*1024*1024)
+	qcowFile := filepath.Join(t.TempDir(), "image.qcow2")
+	if err := os.WriteFile(qcowFile, qcowHeader, 0o600); err != nil {
+		t.Fatalf("Failed to write qcow image (%v)", err)
+	}
+	for file, expected := range map[string]struct {
+		format ovirtclient.ImageFormat
+		size   uint64
+	}{
+		qcowFile:            {ovirtclient.ImageFormatCow, 10 * 1024 * 1024},
+		"./testimage/image": {ovirtclient.ImageFormatRaw, 1024 * 1024},
+	} {
+		fh, err := os.Open(file)
+		if err != nil {
+			t.Fatalf("Failed to open %s (%v)", file, err)
+		}
+		stat, err := fh.Stat()
+		if err != nil {
+			t.Fatalf("Failed to stat %s (%v)", file, err)
+		}
+		format, size, err := detectImageParameters(fh, uint64(stat.Size()))
+		_ = fh.Close()
+		if err != nil {
+			t.Fatalf("Failed to detect image parameters of %s (%v)", file, err)
+		}
+		if format != expected.format || size != expected.size {
+			t.Fatalf(
+				"incorrect image parameters detected for %s: %s/%d, expected: %s/%d",
+				file,
+				format,
+				size,
+				expected.format,
+				expected.size,
+			)
+		}
+	}
+}
+
+func TestDownloadImageSourceChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("Hello world!"))
+		}),
+	)
+	t.Cleanup(server.Close)
+
+	checksum := sha256.Sum256([]byte("Hello world!"))
+	downloadedFile, downloadedChecksum, err := downloadImageSource(
+		context.Background(),
+		server.URL,
+		hex.EncodeToString(checksum[:]),
+	)
+	if err != nil {
+		t.Fatalf("Failed to download image with matching checksum (%v)", err)
+	}
+	_ = os.Remove(downloadedFile)
+	if downloadedChecksum != hex.EncodeToString(checksum[:]) {
+		t.Fatalf("incorrect checksum returned: %s", downloadedChecksum)
+	}
+
+	otherChecksum := sha256.Sum256([]byte("Goodbye world!"))
+	if _, _, err := downloadImageSource(context.Background(), server.URL, hex.EncodeToString(otherChecksum[:])); err == nil {
+		t.Fatalf("Download with mismatching checksum did not result in an error")
+	}
+}
+
+func gzipTestImage(t *testing.T) []byte {
+	image, err := os.ReadFile("./testimage/image")
+	if err != nil {
+		t.Fatalf("Failed to read test image (%v)", err)
+	}
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	if _, err := gz.Write(image); err != nil {
+		t.Fatalf("Failed to compress test image (%v)", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to compress test image (%v)", err)
+	}
+	return buf.Bytes()
+}
+
+func xzTestImage(t *testing.T) []byte {
+	image, err := os.ReadFile("./testimage/image")
+	if err != nil {
+		t.Fatalf("Failed to read test image (%v)", err)
+	}
+	buf := &bytes.Buffer{}
+	w, err := xz.NewWriter(buf)
+	if err != nil {
+		t.Fatalf("Failed to create xz writer (%v)", err)
+	}
+	if _, err := w.Write(image); err != nil {
+		t.Fatalf("Failed to compress test image (%v)", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to compress test image (%v)", err)
+	}
+	return buf.Bytes()
+}
+
+func zstdTestImage(t *testing.T) []byte {
+	image, err := os.ReadFile("./testimage/image")
+	if err != nil {
+		t.Fatalf("Failed to read test image (%v)", err)
+	}
+	buf := &bytes.Buffer{}
+	w, err := zstd.NewWriter(buf)
+	if err != nil {
+		t.Fatalf("Failed to create zstd writer (%v)", err)
+	}
+	if _, err := w.Write(image); err != nil {
+		t.Fatalf("Failed to compress test image (%v)", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to compress test image (%v)", err)
+	}
+	return buf.Bytes()
+}
@@ -2,6 +2,7 @@ package ovirt
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -29,12 +30,14 @@ var networkSchema = map[string]*schema.Schema{
 	},
 	"description": {
 		Type:        schema.TypeString,
-		Required:    true,
+		Optional:    true,
+		Default:     "",
 		Description: "Description of the network.",
 	},
 	"comment": {
 		Type:        schema.TypeString,
-		Required:    true,
+		Optional:    true,
+		Default:     "",
 		Description: "Comment of the network.",
 	},
 	"id": {
@@ -49,8 +52,11 @@ func (p *provider) networkResource() *schema.Resource {
 		ReadContext:   p.networkRead,
 		DeleteContext: p.networkDelete,
 		UpdateContext: p.networkUpdate,
-		Schema:        networkSchema,
-		Description:   "The ovirt_network resource creates networks in oVirt.",
+		Importer: &schema.ResourceImporter{
+			StateContext: p.networkImport,
+		},
+		Schema:      networkSchema,
+		Description: "The ovirt_network resource creates networks in oVirt.",
 	}
 }
 
@@ -79,6 +85,10 @@ func (p *provider) networkRead(ctx context.Context, data *schema.ResourceData, _
 	networkID := data.Id()
 	network, err := client.GetNetwork(ovirtclient.NetworkID(networkID))
 	if err != nil {
+		if isNotFound(err) {
+			data.SetId("")
+			return nil
+		}
 		return errorToDiags("get network", err)
 	}
 	return networkResourceUpdate(network, data)
@@ -118,6 +128,21 @@ func (p *provider) networkDelete(ctx context.Context, data *schema.ResourceData,
 	return nil
 }
 
+func (p *provider) networkImport(ctx context.Context, data *schema.ResourceData, _ interface{}) (
+	[]*schema.ResourceData,
+	error,
+) {
+	client := p.client.WithContext(ctx)
+	network, err := client.GetNetwork(ovirtclient.NetworkID(data.Id()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to import network %s (%w)", data.Id(), err)
+	}
+	if err := diagsToError(networkResourceUpdate(network, data)); err != nil {
+		return nil, fmt.Errorf("failed to import network %s (%w)", data.Id(), err)
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
 func networkResourceUpdate(network ovirtclient.Network, data *schema.ResourceData) diag.Diagnostics {
 	if err := data.Set("name", network.Name()); err != nil {
 		return diag.FromErr(err)
@@ -2,6 +2,8 @@ package ovirt
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -40,8 +42,11 @@ func (p *provider) resourceNetworkAttachment() *schema.Resource {
 		CreateContext: p.resourceNetworkAttachmentCreate,
 		ReadContext:   p.resourceNetworkAttachmentRead,
 		DeleteContext: p.resourceNetworkAttachmentDelete,
-		Schema:        resourceNetworkAttachmentSchema,
-		Description:   "The ovirt_resource_network_attachment resource creates network attachments in oVirt.",
+		Importer: &schema.ResourceImporter{
+			StateContext: p.resourceNetworkAttachmentImport,
+		},
+		Schema:      resourceNetworkAttachmentSchema,
+		Description: "The ovirt_resource_network_attachment resource creates network attachments in oVirt. Existing network attachments can be imported using `HostID/HostNICID/NetworkAttachmentID`.",
 	}
 }
 
@@ -68,6 +73,10 @@ func (p *provider) resourceNetworkAttachmentRead(ctx context.Context, data *sche
 	hostNicID := data.Get("host_nic_id").(string)
 	networkAttachment, err := client.GetNetworkAttachment(ovirtclient.NetworkAttachmentID(networkAttachmentID), ovirtclient.HostID(hostID), ovirtclient.HostNICID(hostNicID))
 	if err != nil {
+		if isNotFound(err) {
+			data.SetId("")
+			return nil
+		}
 		return errorToDiags("get network attachment", err)
 	}
 	return resourceNetworkAttachmentUpdate(networkAttachment, data)
@@ -79,13 +88,61 @@ func (p *provider) resourceNetworkAttachmentDelete(ctx context.Context, data *sc
 	hostID := data.Get("host_id").(string)
 	hostNicID := data.Get("host_nic_id").(string)
 	err := client.DetachNetworkFromHost(ovirtclient.NetworkAttachmentID(networkAttachmentID), ovirtclient.HostID(hostID), ovirtclient.HostNICID(hostNicID))
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		return errorToDiags("delete network attachment", err)
 	}
 	data.SetId("")
 	return nil
 }
 
+func (p *provider) resourceNetworkAttachmentImport(ctx context.Context, data *schema.ResourceData, _ interface{}) (
+	[]*schema.ResourceData,
+	error,
+) {
+	client := p.client.WithContext(ctx)
+	importID := data.Id()
+
+	hostID, hostNICID, networkAttachmentID, err := parseNetworkAttachmentImportID(importID)
+	if err != nil {
+		return nil, err
+	}
+	networkAttachment, err := client.GetNetworkAttachment(networkAttachmentID, hostID, hostNICID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import network attachment %s (%w)", importID, err)
+	}
+	if err := diagsToError(resourceNetworkAttachmentUpdate(networkAttachment, data)); err != nil {
+		return nil, fmt.Errorf("failed to import network attachment %s (%w)", importID, err)
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
+// parseNetworkAttachmentImportID splits an import ID in the form HostID/HostNICID/NetworkAttachmentID into its parts.
+func parseNetworkAttachmentImportID(importID string) (
+	ovirtclient.HostID,
+	ovirtclient.HostNICID,
+	ovirtclient.NetworkAttachmentID,
+	error,
+) {
+	parts := strings.Split(importID, "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf(
+			"invalid import specification %s, the ID should be specified as: HostID/HostNICID/NetworkAttachmentID",
+			importID,
+		)
+	}
+	for _, part := range parts {
+		if !uuidRegexp.MatchString(part) {
+			return "", "", "", fmt.Errorf(
+				"invalid import specification %s, %q is not a UUID, the ID should be specified as: "+
+					"HostID/HostNICID/NetworkAttachmentID",
+				importID,
+				part,
+			)
+		}
+	}
+	return ovirtclient.HostID(parts[0]), ovirtclient.HostNICID(parts[1]), ovirtclient.NetworkAttachmentID(parts[2]), nil
+}
+
 func resourceNetworkAttachmentUpdate(networkAttachment ovirtclient.NetworkAttachment, data *schema.ResourceData) diag.Diagnostics {
 	if err := data.Set("host_id", string(networkAttachment.HostID())); err != nil {
 		return diag.FromErr(err)
@@ -0,0 +1,51 @@
+package ovirt
+
+import (
+	"testing"
+)
+
+func TestParseNetworkAttachmentImportID(t *testing.T) {
+	t.Parallel()
+
+	const hostID = "5b8c2a4e-1d7f-4c3a-9e2b-6f0a8d1c3e5f"
+	const hostNICID = "0c9e1f3a-7b2d-4e6c-8a5f-2d4b6c8e0a1b"
+	const networkAttachmentID = "9e7d5c3b-1a2f-4b6d-8c0e-4f6a8b0c2d4e"
+	importID := hostID + "/" + hostNICID + "/" + networkAttachmentID
+	parsedHostID, parsedHostNICID, parsedNetworkAttachmentID, err := parseNetworkAttachmentImportID(importID)
+	if err != nil {
+		t.Fatalf("failed to parse import ID %s (%v)", importID, err)
+	}
+	if string(parsedHostID) != hostID ||
+		string(parsedHostNICID) != hostNICID ||
+		string(parsedNetworkAttachmentID) != networkAttachmentID {
+		t.Fatalf(
+			"incorrect IDs parsed from %s: %s, %s, %s",
+			importID,
+			parsedHostID,
+			parsedHostNICID,
+			parsedNetworkAttachmentID,
+		)
+	}
+
+	for _, importID := range []string{
+		// Wrong separators
+		hostID + "_" + hostNICID + "_" + networkAttachmentID,
+		hostID + "/" + hostNICID + "_" + networkAttachmentID,
+		hostID + "//" + hostNICID + "/" + networkAttachmentID,
+		// Wrong number of segments
+		"",
+		networkAttachmentID,
+		hostID + "/" + networkAttachmentID,
+		hostID + "/" + hostNICID + "/" + networkAttachmentID + "/",
+		hostID + "/" + hostNICID + "/" + networkAttachmentID + "/" + networkAttachmentID,
+		// Segments that are not UUIDs
+		"host/" + hostNICID + "/" + networkAttachmentID,
+		hostID + "/eth0/" + networkAttachmentID,
+		hostID + "/" + hostNICID + "/attachment",
+		hostID + "/" + hostNICID + "/",
+	} {
+		if _, _, _, err := parseNetworkAttachmentImportID(importID); err == nil {
+			t.Fatalf("no error returned for malformed import ID %q", importID)
+		}
+	}
+}
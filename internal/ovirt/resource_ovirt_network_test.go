@@ -0,0 +1,89 @@
+package ovirt
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestNetworkResourceImport(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	client := p.getTestHelper().GetClient()
+	vnicProfile, err := client.GetVNICProfile(p.getTestHelper().GetVNICProfileID())
+	if err != nil {
+		t.Fatalf("Failed to get VNIC profile (%v)", err)
+	}
+	network, err := vnicProfile.Network()
+	if err != nil {
+		t.Fatalf("Failed to get network (%v)", err)
+	}
+
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_network" "test" {
+	name           = "%s"
+	data_center_id = "%s"
+	vlan_id        = %d
+	description    = "%s"
+	comment        = "%s"
+}
+`,
+		network.Name(),
+		network.DatacenterID(),
+		network.VlanID(),
+		network.Description(),
+		network.Comment(),
+	)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: p.getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateId:      string(network.ID()),
+				ResourceName:       "ovirt_network.test",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					if name := states[0].Attributes["name"]; name != network.Name() {
+						return fmt.Errorf("incorrect imported name: %s", name)
+					}
+					return nil
+				},
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestNetworkResourceReadNotFound(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t)).(*provider)
+	p.client = p.getTestHelper().GetClient()
+	data := schema.TestResourceDataRaw(t, networkSchema, map[string]interface{}{})
+	data.SetId("00000000-0000-0000-0000-000000000000")
+
+	if diags := p.networkRead(context.Background(), data, nil); diags.HasError() {
+		t.Fatalf("reading a removed network returned an error (%v)", diags)
+	}
+	if data.Id() != "" {
+		t.Fatalf("reading a removed network did not clear the ID (%s)", data.Id())
+	}
+}
@@ -18,8 +18,7 @@ var nicSchema = map[string]*schema.Schema{
 	"vnic_profile_id": {
 		Type:             schema.TypeString,
 		Required:         true,
-		Description:      "ID of the VNIC profile to associate with this NIC.",
-		ForceNew:         true,
+		Description:      "ID of the VNIC profile to associate with this NIC. Can be changed without recreating the NIC.",
 		ValidateDiagFunc: validateUUID,
 	},
 	"vm_id": {
@@ -33,15 +32,22 @@ var nicSchema = map[string]*schema.Schema{
 		Type:             schema.TypeString,
 		Required:         true,
 		Description:      "Human-readable name for the NIC.",
-		ForceNew:         true,
 		ValidateDiagFunc: validateNonEmpty,
 	},
+	"mac": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		Description:      "MAC address of the NIC. If not set, oVirt assigns one from the MAC address pool.",
+		ValidateDiagFunc: validateMACAddress,
+	},
 }
 
 func (p *provider) nicResource() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: p.nicCreate,
 		ReadContext:   p.nicRead,
+		UpdateContext: p.nicUpdate,
 		DeleteContext: p.nicDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: p.nicImport,
@@ -57,11 +63,20 @@ func (p *provider) nicCreate(ctx context.Context, data *schema.ResourceData, _ i
 	vnicProfileID := data.Get("vnic_profile_id").(string)
 	name := data.Get("name").(string)
 
+	var params ovirtclient.OptionalNICParameters
+	if mac, ok := data.GetOk("mac"); ok {
+		var err error
+		params, err = ovirtclient.CreateNICParams().WithMac(mac.(string))
+		if err != nil {
+			return errorToDiags("set MAC address for NIC", err)
+		}
+	}
+
 	nic, err := client.CreateNIC(
 		ovirtclient.VMID(vmID),
 		ovirtclient.VNICProfileID(vnicProfileID),
 		name,
-		nil,
+		params,
 	)
 	if err != nil {
 		return errorToDiags("create NIC", err)
@@ -88,6 +103,37 @@ func (p *provider) nicRead(ctx context.Context, data *schema.ResourceData, _ int
 	return nicResourceUpdate(nic, data)
 }
 
+func (p *provider) nicUpdate(ctx context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	client := p.client.WithContext(ctx)
+	id := data.Id()
+	vmID := data.Get("vm_id").(string)
+
+	params := ovirtclient.UpdateNICParams()
+	if data.HasChange("name") {
+		params = params.MustWithName(data.Get("name").(string))
+	}
+	if data.HasChange("vnic_profile_id") {
+		params = params.MustWithVNICProfileID(ovirtclient.VNICProfileID(data.Get("vnic_profile_id").(string)))
+	}
+	if data.HasChange("mac") {
+		params = params.MustWithMac(data.Get("mac").(string))
+	}
+
+	nic, err := client.UpdateNIC(
+		ovirtclient.VMID(vmID),
+		ovirtclient.NICID(id),
+		params,
+	)
+	if err != nil {
+		if isNotFound(err) {
+			// The NIC was removed outside of Terraform, drop it from the state so it is recreated.
+			data.SetId("")
+		}
+		return errorToDiags("update NIC", err)
+	}
+	return nicResourceUpdate(nic, data)
+}
+
 func (p *provider) nicDelete(ctx context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	client := p.client.WithContext(ctx)
 	id := data.Id()
@@ -136,5 +182,6 @@ func nicResourceUpdate(nic ovirtclient.NIC, data *schema.ResourceData) diag.Diag
 	diags = setResourceField(data, "vnic_profile_id", nic.VNICProfileID(), diags)
 	diags = setResourceField(data, "name", nic.Name(), diags)
 	diags = setResourceField(data, "vm_id", nic.VMID(), diags)
+	diags = setResourceField(data, "mac", nic.Mac(), diags)
 	return diags
 }
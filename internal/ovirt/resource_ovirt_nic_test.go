@@ -1,12 +1,15 @@
 package ovirt
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ovirtclientlog "github.com/ovirt/go-ovirt-client-log/v3"
 	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
 )
 
@@ -128,3 +131,119 @@ resource "ovirt_nic" "test" {
 		},
 	})
 }
+
+func TestNICResourceUpdate(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	client := p.getTestHelper().GetClient()
+	clusterID := p.getTestHelper().GetClusterID()
+	templateID := p.getTestHelper().GetBlankTemplateID()
+	vnicProfileID := p.getTestHelper().GetVNICProfileID()
+	vnicProfile, err := client.GetVNICProfile(vnicProfileID)
+	if err != nil {
+		t.Fatalf("Failed to get VNIC profile (%v)", err)
+	}
+	secondVNICProfile, err := client.CreateVNICProfile(
+		p.getTestHelper().GenerateTestResourceName(t),
+		vnicProfile.NetworkID(),
+		ovirtclient.CreateVNICProfileParams(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create VNIC profile (%v)", err)
+	}
+
+	configTemplate := `
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_vm" "test" {
+	cluster_id  = "%s"
+	template_id = "%s"
+	name        = "test"
+}
+
+resource "ovirt_nic" "test" {
+	vm_id           = ovirt_vm.test.id
+	vnic_profile_id = "%s"
+	name            = "%s"
+	mac             = "%s"
+}
+`
+	var nicID string
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: p.getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configTemplate, clusterID, templateID, vnicProfileID, "eth0", "00:1a:4a:16:01:51"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ovirt_nic.test", "mac", "00:1a:4a:16:01:51"),
+					func(state *terraform.State) error {
+						nicID = state.RootModule().Resources["ovirt_nic.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(
+					configTemplate,
+					clusterID,
+					templateID,
+					secondVNICProfile.ID(),
+					"eth1",
+					"00:1a:4a:16:01:52",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ovirt_nic.test", "name", "eth1"),
+					resource.TestCheckResourceAttr("ovirt_nic.test", "mac", "00:1a:4a:16:01:52"),
+					resource.TestCheckResourceAttr("ovirt_nic.test", "vnic_profile_id", string(secondVNICProfile.ID())),
+					func(state *terraform.State) error {
+						if id := state.RootModule().Resources["ovirt_nic.test"].Primary.ID; id != nicID {
+							return fmt.Errorf("NIC was recreated instead of updated (%s != %s)", id, nicID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestNICResourceUpdateNotFound(t *testing.T) {
+	t.Parallel()
+
+	// Special case: we are using the ovirtclientlog.NewTestLogger here because we call the client methods outside of
+	// the Terraform context.
+	p := newProvider(ovirtclientlog.NewTestLogger(t)).(*provider)
+	p.client = p.getTestHelper().GetClient()
+	vm, err := p.client.CreateVM(
+		p.getTestHelper().GetClusterID(),
+		p.getTestHelper().GetBlankTemplateID(),
+		p.getTestHelper().GenerateTestResourceName(t),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create test VM (%v)", err)
+	}
+	nic, err := p.client.CreateNIC(vm.ID(), p.getTestHelper().GetVNICProfileID(), "eth0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create NIC (%v)", err)
+	}
+	if err := p.client.RemoveNIC(vm.ID(), nic.ID()); err != nil {
+		t.Fatalf("Failed to remove NIC (%v)", err)
+	}
+
+	data := schema.TestResourceDataRaw(t, nicSchema, map[string]interface{}{
+		"vm_id":           string(vm.ID()),
+		"vnic_profile_id": string(p.getTestHelper().GetVNICProfileID()),
+		"name":            "eth1",
+	})
+	data.SetId(string(nic.ID()))
+	if diags := p.nicUpdate(context.Background(), data, nil); !diags.HasError() {
+		t.Fatalf("updating a removed NIC did not return an error")
+	}
+	if data.Id() != "" {
+		t.Fatalf("updating a removed NIC did not clear the ID (%s)", data.Id())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -60,9 +61,23 @@ var vmSchema = map[string]*schema.Schema{
 			strings.Join(ovirtclient.VMStatusValues().Strings(), "`, `"),
 		),
 	},
+	"host_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the host the VM is currently running on. Empty if the VM is not running.",
+	},
+	"reported_ips": {
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "IP addresses reported by the guest agent of the running VM. The keys are the network interface names in the guest, the values are comma-separated lists of addresses.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
 	"cpu_mode": {
 		Type:     schema.TypeString,
 		Optional: true,
+		Computed: true,
 		ForceNew: false,
 		Description: fmt.Sprintf(
 			"Sets the CPU mode for the VM. Can be one of: %s",
@@ -73,6 +88,7 @@ var vmSchema = map[string]*schema.Schema{
 	"cpu_cores": {
 		Type:             schema.TypeInt,
 		Optional:         true,
+		Computed:         true,
 		ForceNew:         false,
 		RequiredWith:     []string{"cpu_sockets", "cpu_threads"},
 		Description:      "Number of CPU cores to allocate to the VM. If set, cpu_threads and cpu_sockets must also be specified.",
@@ -81,6 +97,7 @@ var vmSchema = map[string]*schema.Schema{
 	"cpu_threads": {
 		Type:             schema.TypeInt,
 		Optional:         true,
+		Computed:         true,
 		ForceNew:         false,
 		RequiredWith:     []string{"cpu_sockets", "cpu_cores"},
 		Description:      "Number of CPU threads to allocate to the VM. If set, cpu_cores and cpu_sockets must also be specified.",
@@ -89,6 +106,7 @@ var vmSchema = map[string]*schema.Schema{
 	"cpu_sockets": {
 		Type:             schema.TypeInt,
 		Optional:         true,
+		Computed:         true,
 		ForceNew:         false,
 		RequiredWith:     []string{"cpu_threads", "cpu_cores"},
 		Description:      "Number of CPU sockets to allocate to the VM. If set, cpu_cores and cpu_threads must also be specified.",
@@ -166,24 +184,91 @@ var vmSchema = map[string]*schema.Schema{
 	"initialization_custom_script": {
 		Type:        schema.TypeString,
 		Optional:    true,
+		Computed:    true,
 		ForceNew:    true,
 		Description: "Custom script that passed to VM during initialization.",
 	},
 	"initialization_hostname": {
 		Type:        schema.TypeString,
 		Optional:    true,
+		Computed:    true,
 		ForceNew:    true,
 		Description: "hostname that is set during initialization.",
 	},
+	"initialization_nic_configuration": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Description: "Static IP configuration for a network interface in the guest that is set during initialization.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ForceNew:         true,
+					Description:      "Name of the network interface in the guest operating system, for example `eth0`.",
+					ValidateDiagFunc: validateNonEmpty,
+				},
+				"ipv4_address": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ForceNew:         true,
+					Description:      "Static IPv4 address of the network interface.",
+					ValidateDiagFunc: validateIPAddress,
+				},
+				"ipv4_netmask": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ForceNew:         true,
+					Description:      "Netmask of the IPv4 address, for example `255.255.255.0` or `24`.",
+					ValidateDiagFunc: validateIPv4Netmask,
+				},
+				"ipv4_gateway": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ForceNew:         true,
+					Description:      "IPv4 default gateway.",
+					ValidateDiagFunc: validateIPAddress,
+				},
+				"ipv6_address": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ForceNew:         true,
+					Description:      "Static IPv6 address of the network interface.",
+					ValidateDiagFunc: validateIPAddress,
+				},
+				"ipv6_netmask": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ForceNew:         true,
+					RequiredWith:     []string{"initialization_nic_configuration.0.ipv6_address"},
+					Description:      "Prefix length of the IPv6 address between 0 and 128, for example `64`.",
+					ValidateDiagFunc: validateIPv6PrefixLength,
+				},
+				"ipv6_gateway": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ForceNew:         true,
+					RequiredWith:     []string{"initialization_nic_configuration.0.ipv6_address"},
+					Description:      "IPv6 default gateway.",
+					ValidateDiagFunc: validateIPAddress,
+				},
+			},
+		},
+	},
 	"memory": {
 		Type:             schema.TypeInt,
 		Optional:         true,
+		Computed:         true,
 		Description:      "Memory to assign to the VM in bytes.",
 		ValidateDiagFunc: validatePositiveInt,
 	},
 	"maximum_memory": {
 		Type:             schema.TypeInt,
 		Optional:         true,
+		Computed:         true,
 		ForceNew:         true,
 		Description:      "Maximum memory to assign to the VM in the memory policy in bytes.",
 		ValidateDiagFunc: validatePositiveInt,
@@ -192,6 +277,7 @@ var vmSchema = map[string]*schema.Schema{
 	"memory_ballooning": {
 		Type:        schema.TypeBool,
 		Optional:    true,
+		Computed:    true,
 		ForceNew:    true,
 		Description: "Turn memory ballooning on or off for the VM.",
 	},
@@ -210,6 +296,7 @@ var vmSchema = map[string]*schema.Schema{
 	"instance_type_id": {
 		Type:             schema.TypeString,
 		Optional:         true,
+		Computed:         true,
 		Description:      "Defines the VM instance type ID overrides the hardware parameters of the created VM.",
 		ValidateDiagFunc: validateUUID,
 	},
@@ -221,10 +308,58 @@ var vmSchema = map[string]*schema.Schema{
 	"huge_pages": {
 		Type:             schema.TypeInt,
 		Optional:         true,
+		Computed:         true,
 		ForceNew:         true,
 		Description:      "Sets the HugePages setting for the VM. Must be one of: " + strings.Join(vmHugePagesValues(), ", "),
 		ValidateDiagFunc: validateHugePages,
 	},
+	"restart_policy": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  string(VMRestartPolicyNever),
+		Description: fmt.Sprintf(
+			"Controls if the VM is restarted when an update is applied while it is running. Use `%s` to only save changes that need a restart for the next run, `%s` to restart the VM if one of `%s` changed, or `%s` to restart it on every update other than a change of `restart_policy` itself. A VM that does not shut down within 5 minutes is powered off. Must be one of: %s",
+			VMRestartPolicyNever,
+			VMRestartPolicyWhenRequired,
+			strings.Join(vmRestartRequiredFields(), "`, `"),
+			VMRestartPolicyAlways,
+			strings.Join(vmRestartPolicyValues(), ", "),
+		),
+		ValidateDiagFunc: validateEnum(vmRestartPolicyValues()),
+	},
+	"pending_next_run_changes": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "List of fields that have been updated on the running VM, but only take effect after the VM is restarted.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+}
+
+// VMRestartPolicy describes when a running VM is restarted after an update.
+type VMRestartPolicy string
+
+const (
+	// VMRestartPolicyNever never restarts the VM. Changes that need a restart are saved for the next run.
+	VMRestartPolicyNever VMRestartPolicy = "never"
+	// VMRestartPolicyWhenRequired restarts the VM if a change cannot be applied to the running VM.
+	VMRestartPolicyWhenRequired VMRestartPolicy = "when_required"
+	// VMRestartPolicyAlways restarts the VM on every update, except when only the restart policy itself changed.
+	VMRestartPolicyAlways VMRestartPolicy = "always"
+)
+
+func vmRestartPolicyValues() []string {
+	return []string{
+		string(VMRestartPolicyNever),
+		string(VMRestartPolicyWhenRequired),
+		string(VMRestartPolicyAlways),
+	}
+}
+
+// vmRestartRequiredFields lists the fields that oVirt only applies to a running VM after a restart.
+func vmRestartRequiredFields() []string {
+	return []string{"cpu_cores", "cpu_threads", "cpu_sockets", "memory"}
 }
 
 func provisioningValues() []string {
@@ -337,7 +472,8 @@ func (p *provider) vmCreate(
 		}
 	}
 
-	return vmResourceUpdate(vm, data)
+	diags = vmResourceUpdate(vm, data)
+	return vmReportedIPsResourceUpdate(client, vm, data, diags)
 }
 
 func handleSoundcardEnabled(
@@ -714,9 +850,21 @@ func handleVMInitialization(
 		vmInitScript = hInitScript.(string)
 		useInit = true
 	}
+	var nicConfiguration ovirtclient.NicConfiguration
+	if nicConfigurations, ok := data.GetOk("initialization_nic_configuration"); ok {
+		nicConfigurationList := nicConfigurations.([]interface{})
+		if len(nicConfigurationList) == 1 {
+			nicConfiguration = vmNicConfigurationFromMap(nicConfigurationList[0].(map[string]interface{}))
+			useInit = true
+		}
+	}
 
 	if useInit {
-		_, err := params.WithInitialization(ovirtclient.NewInitialization(vmInitScript, vmHostname))
+		init := ovirtclient.NewInitialization(vmInitScript, vmHostname)
+		if nicConfiguration != nil {
+			init = init.WithNicConfiguration(nicConfiguration)
+		}
+		_, err := params.WithInitialization(init)
 		if err != nil {
 			diags = append(diags, errorToDiag("add Initialization parameters", err))
 		}
@@ -725,6 +873,48 @@ func handleVMInitialization(
 	return diags
 }
 
+func vmNicConfigurationFromMap(entry map[string]interface{}) ovirtclient.NicConfiguration {
+	nicConfiguration := ovirtclient.NewNicConfiguration(
+		entry["name"].(string),
+		ovirtclient.IP{
+			Address: entry["ipv4_address"].(string),
+			Gateway: entry["ipv4_gateway"].(string),
+			Netmask: entry["ipv4_netmask"].(string),
+			Version: ovirtclient.IPVERSION_V4,
+		},
+	)
+	if ipv6Address := entry["ipv6_address"].(string); ipv6Address != "" {
+		nicConfiguration = nicConfiguration.WithIPV6(
+			ovirtclient.IP{
+				Address: ipv6Address,
+				Gateway: entry["ipv6_gateway"].(string),
+				Netmask: entry["ipv6_netmask"].(string),
+				Version: ovirtclient.IPVERSION_V6,
+			},
+		)
+	}
+	return nicConfiguration
+}
+
+func vmNicConfigurationToMap(nicConfiguration ovirtclient.NicConfiguration) map[string]interface{} {
+	ipv4 := nicConfiguration.IP()
+	entry := map[string]interface{}{
+		"name":         nicConfiguration.Name(),
+		"ipv4_address": ipv4.Address,
+		"ipv4_netmask": ipv4.Netmask,
+		"ipv4_gateway": ipv4.Gateway,
+		"ipv6_address": "",
+		"ipv6_netmask": "",
+		"ipv6_gateway": "",
+	}
+	if ipv6 := nicConfiguration.IPV6(); ipv6 != nil {
+		entry["ipv6_address"] = ipv6.Address
+		entry["ipv6_netmask"] = ipv6.Netmask
+		entry["ipv6_gateway"] = ipv6.Gateway
+	}
+	return entry
+}
+
 func handleVMInstanceTypeID(
 	_ ovirtclient.Client,
 	data *schema.ResourceData,
@@ -761,7 +951,8 @@ func (p *provider) vmRead(
 			},
 		}
 	}
-	return vmResourceUpdate(vm, data)
+	diags := vmResourceUpdate(vm, data)
+	return vmReportedIPsResourceUpdate(client, vm, data, diags)
 }
 
 // vmResourceUpdate takes the VM object and converts it into Terraform resource data.
@@ -777,6 +968,37 @@ func vmResourceUpdate(vm ovirtclient.VMData, data *schema.ResourceData) diag.Dia
 	diags = setResourceField(data, "comment", vm.Comment(), diags)
 	diags = setResourceField(data, "description", vm.Description(), diags)
 	diags = setResourceField(data, "status", vm.Status(), diags)
+	hostID := ""
+	if id := vm.HostID(); id != nil {
+		hostID = string(*id)
+	}
+	diags = setResourceField(data, "host_id", hostID, diags)
+	pendingChangesList := vmPendingNextRunChanges(vm, data)
+	diags = setResourceField(data, "pending_next_run_changes", pendingChangesList, diags)
+	pendingChanges := map[string]bool{}
+	for _, field := range pendingChangesList {
+		pendingChanges[field] = true
+	}
+	diags = vmCPUResourceUpdate(vm, data, pendingChanges, diags)
+	diags = vmMemoryResourceUpdate(vm, data, pendingChanges, diags)
+	if hugePages := vm.HugePages(); hugePages != nil {
+		diags = setResourceField(data, "huge_pages", int(*hugePages), diags)
+	}
+	if instanceTypeID := vm.InstanceTypeID(); instanceTypeID != nil {
+		diags = setResourceField(data, "instance_type_id", string(*instanceTypeID), diags)
+	}
+	if init := vm.Initialization(); init != nil {
+		diags = setResourceField(data, "initialization_hostname", init.HostName(), diags)
+		diags = setResourceField(data, "initialization_custom_script", init.CustomScript(), diags)
+		if nicConfiguration := init.NicConfiguration(); nicConfiguration != nil {
+			diags = setResourceField(
+				data,
+				"initialization_nic_configuration",
+				[]map[string]interface{}{vmNicConfigurationToMap(nicConfiguration)},
+				diags,
+			)
+		}
+	}
 	if _, ok := data.GetOk("os_type"); ok || vm.OS().Type() != "other" {
 		diags = setResourceField(data, "os_type", vm.OS().Type(), diags)
 	}
@@ -790,6 +1012,89 @@ func vmResourceUpdate(vm ovirtclient.VMData, data *schema.ResourceData) diag.Dia
 	return diags
 }
 
+// vmReportedIPsResourceUpdate fetches the IP addresses the guest agent reports for a running VM and writes them into
+// the resource data. Failing to fetch the addresses only results in a warning as they are informational. In this case
+// the addresses are cleared so no outdated addresses from a previous run remain in the state.
+func vmReportedIPsResourceUpdate(
+	client ovirtclient.Client,
+	vm ovirtclient.VMData,
+	data *schema.ResourceData,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	reportedIPs := map[string]string{}
+	if vm.Status() == ovirtclient.VMStatusUp {
+		result, err := client.GetVMIPAddresses(vm.ID(), ovirtclient.NewVMIPSearchParams())
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to fetch reported IP addresses of VM %s", vm.ID()),
+				Detail:   err.Error(),
+			})
+			return setResourceField(data, "reported_ips", reportedIPs, diags)
+		}
+		for interfaceName, ips := range result {
+			addresses := make([]string, len(ips))
+			for i, ip := range ips {
+				addresses[i] = ip.String()
+			}
+			reportedIPs[interfaceName] = strings.Join(addresses, ",")
+		}
+	}
+	return setResourceField(data, "reported_ips", reportedIPs, diags)
+}
+
+// vmCPUResourceUpdate writes the CPU mode and topology into the resource data. Fields waiting for a restart are
+// skipped as oVirt still reports the values of the running VM for them.
+func vmCPUResourceUpdate(
+	vm ovirtclient.VMData,
+	data *schema.ResourceData,
+	pendingChanges map[string]bool,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	cpu := vm.CPU()
+	if cpu == nil {
+		return diags
+	}
+	if mode := cpu.Mode(); mode != nil {
+		diags = setResourceField(data, "cpu_mode", string(*mode), diags)
+	}
+	topo := cpu.Topo()
+	if topo == nil {
+		return diags
+	}
+	for field, value := range map[string]uint{
+		"cpu_cores":   topo.Cores(),
+		"cpu_threads": topo.Threads(),
+		"cpu_sockets": topo.Sockets(),
+	} {
+		if !pendingChanges[field] {
+			diags = setResourceField(data, field, int(value), diags)
+		}
+	}
+	return diags
+}
+
+// vmMemoryResourceUpdate writes the memory and memory policy into the resource data.
+func vmMemoryResourceUpdate(
+	vm ovirtclient.VMData,
+	data *schema.ResourceData,
+	pendingChanges map[string]bool,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	if !pendingChanges["memory"] {
+		diags = setResourceField(data, "memory", int(vm.Memory()), diags)
+	}
+	memoryPolicy := vm.MemoryPolicy()
+	if memoryPolicy == nil {
+		return diags
+	}
+	if maxMemory := memoryPolicy.Max(); maxMemory != nil {
+		diags = setResourceField(data, "maximum_memory", int(*maxMemory), diags)
+	}
+	diags = setResourceField(data, "memory_ballooning", memoryPolicy.Ballooning(), diags)
+	return diags
+}
+
 func (p *provider) vmDelete(ctx context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	client := p.client.WithContext(ctx)
 	if err := client.RemoveVM(ovirtclient.VMID(data.Id())); err != nil {
@@ -931,7 +1236,116 @@ func (p *provider) vmUpdate(ctx context.Context, data *schema.ResourceData, _ in
 		)
 		return diags
 	}
-	return vmResourceUpdate(vm, data)
+
+	pendingChanges := vmPendingNextRunChanges(vm, data)
+	restartPolicy := VMRestartPolicy(data.Get("restart_policy").(string))
+	// Changing only the restart policy itself must not restart the VM.
+	onlyPolicyChanged := !data.HasChangeExcept("restart_policy")
+	if vm.Status() == ovirtclient.VMStatusUp &&
+		((restartPolicy == VMRestartPolicyAlways && !onlyPolicyChanged) ||
+			(restartPolicy == VMRestartPolicyWhenRequired && len(pendingChanges) > 0)) {
+		vm, diags = vmRestart(ctx, client, vm.ID(), vmRestartShutdownTimeout)
+		if diags.HasError() {
+			return diags
+		}
+		pendingChanges = []string{}
+	}
+
+	diags = setResourceField(data, "pending_next_run_changes", pendingChanges, diags)
+	diags = append(diags, vmResourceUpdate(vm, data)...)
+	diags = vmReportedIPsResourceUpdate(client, vm, data, diags)
+	if len(pendingChanges) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("VM %s needs a restart to apply all changes", vm.ID()),
+			Detail: fmt.Sprintf(
+				"The following fields have been saved for the next run of the VM and take effect after it is restarted: %s. Set restart_policy to \"%s\" to let Terraform restart the VM.",
+				strings.Join(pendingChanges, ", "),
+				VMRestartPolicyWhenRequired,
+			),
+		})
+	}
+	return diags
+}
+
+// vmPendingNextRunChanges returns the fields that are only applied after a restart, including those left over from
+// previous updates. A field stays pending only while the value reported by the engine differs from the desired value,
+// so the list also clears when the VM is restarted outside of Terraform. The list is empty if the VM is not running
+// since the changes are applied on the next start.
+func vmPendingNextRunChanges(vm ovirtclient.VMData, data *schema.ResourceData) []string {
+	pendingChanges := []string{}
+	if vm.Status() != ovirtclient.VMStatusUp {
+		return pendingChanges
+	}
+	previousChanges := map[string]bool{}
+	for _, field := range data.Get("pending_next_run_changes").([]interface{}) {
+		previousChanges[field.(string)] = true
+	}
+	reportedValues := vmRestartRequiredValues(vm)
+	for _, field := range vmRestartRequiredFields() {
+		if !previousChanges[field] && !data.HasChange(field) {
+			continue
+		}
+		if reportedValue, ok := reportedValues[field]; ok && reportedValue != data.Get(field).(int) {
+			pendingChanges = append(pendingChanges, field)
+		}
+	}
+	return pendingChanges
+}
+
+// vmRestartRequiredValues returns the values the engine reports for the fields in vmRestartRequiredFields. Fields the
+// engine does not report are left out.
+func vmRestartRequiredValues(vm ovirtclient.VMData) map[string]int {
+	values := map[string]int{
+		"memory": int(vm.Memory()),
+	}
+	if cpu := vm.CPU(); cpu != nil {
+		if topo := cpu.Topo(); topo != nil {
+			values["cpu_cores"] = int(topo.Cores())
+			values["cpu_threads"] = int(topo.Threads())
+			values["cpu_sockets"] = int(topo.Sockets())
+		}
+	}
+	return values
+}
+
+// vmRestartShutdownTimeout is the time a VM is given to shut down gracefully during a restart before it is powered
+// off.
+const vmRestartShutdownTimeout = 5 * time.Minute
+
+// vmRestart shuts down the VM and starts it again so the next run configuration is applied. If the guest does not
+// react to the ACPI shutdown within shutdownTimeout, the VM is powered off instead.
+func vmRestart(
+	ctx context.Context,
+	client ovirtclient.Client,
+	id ovirtclient.VMID,
+	shutdownTimeout time.Duration,
+) (ovirtclient.VM, diag.Diagnostics) {
+	if err := client.ShutdownVM(id, false); err != nil {
+		return nil, errorToDiags("shut down VM for restart", err)
+	}
+	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	_, err := client.WithContext(shutdownCtx).WaitForVMStatus(id, ovirtclient.VMStatusDown)
+	cancel()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, errorToDiags("wait for VM to shut down", err)
+		}
+		if err := client.StopVM(id, false); err != nil {
+			return nil, errorToDiags("power off VM after failed shutdown", err)
+		}
+		if _, err := client.WaitForVMStatus(id, ovirtclient.VMStatusDown); err != nil {
+			return nil, errorToDiags("wait for VM to stop", err)
+		}
+	}
+	if err := client.StartVM(id); err != nil {
+		return nil, errorToDiags("start VM after restart", err)
+	}
+	vm, err := client.WaitForVMStatus(id, ovirtclient.VMStatusUp)
+	if err != nil {
+		return nil, errorToDiags("wait for VM start", err)
+	}
+	return vm, nil
 }
 
 func (p *provider) vmImport(ctx context.Context, data *schema.ResourceData, _ interface{}) (
@@ -944,6 +1358,8 @@ func (p *provider) vmImport(ctx context.Context, data *schema.ResourceData, _ in
 		return nil, fmt.Errorf("failed to import VM %s (%w)", data.Id(), err)
 	}
 	d := vmResourceUpdate(vm, data)
+	d = vmReportedIPsResourceUpdate(client, vm, data, d)
+	d = setResourceField(data, "restart_policy", string(VMRestartPolicyNever), d)
 	if err := diagsToError(d); err != nil {
 		return nil, fmt.Errorf("failed to import VM %s (%w)", data.Id(), err)
 	}
@@ -3,10 +3,13 @@ package ovirt
 import (
 	"context"
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -142,6 +145,93 @@ resource "ovirt_vm" "foo" {
 	)
 }
 
+func TestVMResourceImportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Special case: we are using the ovirtclientlog.NewTestLogger here because we call the client methods outside of
+	// the Terraform context.
+	p := newProvider(ovirtclientlog.NewTestLogger(t))
+	client := p.getTestHelper().GetClient()
+	clusterID := p.getTestHelper().GetClusterID()
+	templateID := p.getTestHelper().GetBlankTemplateID()
+	name := p.getTestHelper().GenerateTestResourceName(t)
+
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_vm" "foo" {
+	cluster_id        = "%s"
+	template_id       = "%s"
+	name              = "%s"
+	comment           = "Hello world!"
+	cpu_cores         = 2
+	cpu_threads       = 1
+	cpu_sockets       = 2
+	cpu_mode          = "host_passthrough"
+	memory            = 2147483648
+	maximum_memory    = 4294967296
+	memory_ballooning = false
+	huge_pages        = 2048
+}
+`,
+		clusterID,
+		templateID,
+		name,
+	)
+
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config:             config,
+					ImportState:        true,
+					ImportStatePersist: true,
+					ResourceName:       "ovirt_vm.foo",
+					ImportStateIdFunc: func(state *terraform.State) (string, error) {
+						params := ovirtclient.NewCreateVMParams().
+							MustWithComment("Hello world!").
+							MustWithCPU(
+								ovirtclient.NewVMCPUParams().
+									MustWithMode(ovirtclient.CPUModeHostPassthrough).
+									MustWithTopo(ovirtclient.MustNewVMCPUTopo(2, 1, 2)),
+							).
+							MustWithMemory(2147483648).
+							WithMemoryPolicy(
+								ovirtclient.NewMemoryPolicyParameters().
+									MustWithMax(4294967296).
+									MustWithBallooning(false),
+							).
+							MustWithHugePages(ovirtclient.VMHugePages2M)
+						vm, err := client.CreateVM(
+							clusterID,
+							templateID,
+							name,
+							params,
+						)
+						if err != nil {
+							return "", fmt.Errorf("failed to create test VM (%w)", err)
+						}
+						return string(vm.ID()), nil
+					},
+				},
+				{
+					// The imported state must match the configuration without any changes.
+					Config:   config,
+					PlanOnly: true,
+				},
+				{
+					Config:  config,
+					Destroy: true,
+				},
+			},
+		},
+	)
+}
+
 func TestVMResourceOSType(t *testing.T) {
 	t.Parallel()
 
@@ -292,6 +382,89 @@ resource "ovirt_vm" "foo" {
 	)
 }
 
+func TestVMResourceInitializationNicConfiguration(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	clusterID := p.getTestHelper().GetClusterID()
+	templateID := p.getTestHelper().GetBlankTemplateID()
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_vm" "foo" {
+	cluster_id  = "%s"
+	template_id = "%s"
+	name        = "test"
+	initialization_hostname = "vm-test-1"
+	initialization_nic_configuration {
+		name         = "eth0"
+		ipv4_address = "192.168.1.10"
+		ipv4_netmask = "255.255.255.0"
+		ipv4_gateway = "192.168.1.1"
+		ipv6_address = "fd00::10"
+		ipv6_netmask = "64"
+		ipv6_gateway = "fd00::1"
+	}
+}
+`,
+		clusterID,
+		templateID,
+	)
+
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(
+							"ovirt_vm.foo",
+							"initialization_nic_configuration.0.name",
+							"eth0",
+						),
+						resource.TestCheckResourceAttr(
+							"ovirt_vm.foo",
+							"initialization_nic_configuration.0.ipv4_address",
+							"192.168.1.10",
+						),
+						resource.TestCheckResourceAttr(
+							"ovirt_vm.foo",
+							"initialization_nic_configuration.0.ipv6_address",
+							"fd00::10",
+						),
+						func(state *terraform.State) error {
+							vmID := state.RootModule().Resources["ovirt_vm.foo"].Primary.ID
+							vm, err := p.getTestHelper().GetClient().GetVM(ovirtclient.VMID(vmID))
+							if err != nil {
+								return err
+							}
+							nicConfiguration := vm.Initialization().NicConfiguration()
+							if nicConfiguration == nil {
+								return fmt.Errorf("no NIC configuration set on the VM initialization")
+							}
+							if address := nicConfiguration.IP().Address; address != "192.168.1.10" {
+								return fmt.Errorf("incorrect IPv4 address in NIC configuration: %s", address)
+							}
+							if nicConfiguration.IPV6() == nil || nicConfiguration.IPV6().Gateway != "fd00::1" {
+								return fmt.Errorf("incorrect IPv6 configuration in NIC configuration")
+							}
+							return nil
+						},
+					),
+				},
+				{
+					Config:  config,
+					Destroy: true,
+				},
+			},
+		},
+	)
+}
+
 func TestVMResourceCPUParameters(t *testing.T) {
 	t.Parallel()
 
@@ -490,15 +663,23 @@ type testVM struct {
 	id              ovirtclient.VMID
 	name            string
 	comment         string
+	description     string
 	clusterID       ovirtclient.ClusterID
 	templateID      ovirtclient.TemplateID
 	status          ovirtclient.VMStatus
 	os              ovirtclient.VMOS
 	placementPolicy ovirtclient.VMPlacementPolicy
+	cpu             testCPU
+	memory          int64
+	memoryPolicy    ovirtclient.MemoryPolicy
+	hugePages       *ovirtclient.VMHugePages
+	initialization  ovirtclient.Initialization
+	instanceTypeID  *ovirtclient.InstanceTypeID
+	hostID          *ovirtclient.HostID
 }
 
 func (t *testVM) InstanceTypeID() *ovirtclient.InstanceTypeID {
-	panic("not implemented for test input")
+	return t.instanceTypeID
 }
 
 func (t *testVM) VMType() ovirtclient.VMType {
@@ -510,11 +691,11 @@ func (t *testVM) OS() ovirtclient.VMOS {
 }
 
 func (t *testVM) Memory() int64 {
-	panic("not implemented for test input")
+	return t.memory
 }
 
 func (t *testVM) MemoryPolicy() ovirtclient.MemoryPolicy {
-	panic("not implemented for test input")
+	return t.memoryPolicy
 }
 
 func (t *testVM) TagIDs() []ovirtclient.TagID {
@@ -522,15 +703,15 @@ func (t *testVM) TagIDs() []ovirtclient.TagID {
 }
 
 func (t *testVM) HugePages() *ovirtclient.VMHugePages {
-	panic("not implemented for test input")
+	return t.hugePages
 }
 
 func (t *testVM) Initialization() ovirtclient.Initialization {
-	panic("not implemented for test input")
+	return t.initialization
 }
 
 func (t *testVM) HostID() *ovirtclient.HostID {
-	panic("not implemented for test input")
+	return t.hostID
 }
 
 func (t *testVM) PlacementPolicy() (placementPolicy ovirtclient.VMPlacementPolicy, ok bool) {
@@ -550,12 +731,30 @@ func (t testPlacementPolicy) HostIDs() []ovirtclient.HostID {
 	return t.hostIDs
 }
 
+type testMemoryPolicy struct {
+	max        *int64
+	ballooning bool
+}
+
+func (t testMemoryPolicy) Guaranteed() *int64 {
+	return nil
+}
+
+func (t testMemoryPolicy) Max() *int64 {
+	return t.max
+}
+
+func (t testMemoryPolicy) Ballooning() bool {
+	return t.ballooning
+}
+
 type testCPU struct {
 	topo testTopo
+	mode *ovirtclient.CPUMode
 }
 
 func (t testCPU) Mode() *ovirtclient.CPUMode {
-	panic("not implemented for test input")
+	return t.mode
 }
 
 type testTopo struct {
@@ -581,7 +780,7 @@ func (t testCPU) Topo() ovirtclient.VMCPUTopo {
 }
 
 func (t *testVM) CPU() ovirtclient.VMCPU {
-	return testCPU{}
+	return t.cpu
 }
 
 func (t *testVM) ID() ovirtclient.VMID {
@@ -596,6 +795,10 @@ func (t *testVM) Comment() string {
 	return t.comment
 }
 
+func (t *testVM) Description() string {
+	return t.description
+}
+
 func (t *testVM) ClusterID() ovirtclient.ClusterID {
 	return t.clusterID
 }
@@ -620,6 +823,11 @@ func TestVMResourceUpdate(t *testing.T) {
 	t.Parallel()
 
 	vmAffinity := ovirtclient.VMAffinityMigratable
+	cpuMode := ovirtclient.CPUModeHostPassthrough
+	maxMemory := int64(2147483648)
+	hugePages := ovirtclient.VMHugePages2M
+	instanceTypeID := ovirtclient.InstanceTypeID("instance-type-1")
+	hostID := ovirtclient.HostID("host-1")
 	vm := &testVM{
 		id:         "asdf",
 		name:       "test VM",
@@ -634,6 +842,23 @@ func TestVMResourceUpdate(t *testing.T) {
 			&vmAffinity,
 			[]ovirtclient.HostID{"asdf"},
 		},
+		cpu: testCPU{
+			topo: testTopo{
+				cores:   2,
+				threads: 1,
+				sockets: 4,
+			},
+			mode: &cpuMode,
+		},
+		memory: 1073741824,
+		memoryPolicy: testMemoryPolicy{
+			max:        &maxMemory,
+			ballooning: true,
+		},
+		hugePages:      &hugePages,
+		initialization: ovirtclient.NewInitialization("echo hello", "vm-test-1"),
+		instanceTypeID: &instanceTypeID,
+		hostID:         &hostID,
 	}
 	resourceData := schema.TestResourceDataRaw(t, vmSchema, map[string]interface{}{})
 	diags := vmResourceUpdate(vm, resourceData)
@@ -645,9 +870,372 @@ func TestVMResourceUpdate(t *testing.T) {
 	compareResource(t, resourceData, "cluster_id", string(vm.clusterID))
 	compareResource(t, resourceData, "template_id", string(vm.templateID))
 	compareResource(t, resourceData, "status", string(vm.status))
+	compareResource(t, resourceData, "host_id", string(hostID))
 	compareResource(t, resourceData, "os_type", vm.os.Type())
 	compareResource(t, resourceData, "placement_policy_affinity", string(*vm.placementPolicy.Affinity()))
 	compareResourceStringList(t, resourceData, "placement_policy_host_ids", []string{"asdf"})
+	compareResource(t, resourceData, "cpu_mode", string(cpuMode))
+	compareResourceInt(t, resourceData, "cpu_cores", 2)
+	compareResourceInt(t, resourceData, "cpu_threads", 1)
+	compareResourceInt(t, resourceData, "cpu_sockets", 4)
+	compareResourceInt(t, resourceData, "memory", int(vm.memory))
+	compareResourceInt(t, resourceData, "maximum_memory", int(maxMemory))
+	if ballooning := resourceData.Get("memory_ballooning"); ballooning != true {
+		t.Fatalf("invalid resource memory_ballooning: %v, expected: true", ballooning)
+	}
+	compareResourceInt(t, resourceData, "huge_pages", int(hugePages))
+	compareResource(t, resourceData, "instance_type_id", string(instanceTypeID))
+	compareResource(t, resourceData, "initialization_hostname", "vm-test-1")
+	compareResource(t, resourceData, "initialization_custom_script", "echo hello")
+}
+
+func TestVMResourceUpdatePendingNextRunChanges(t *testing.T) {
+	t.Parallel()
+
+	vm := &testVM{
+		id:         "asdf",
+		name:       "test VM",
+		clusterID:  "cluster-1",
+		templateID: "template-1",
+		status:     ovirtclient.VMStatusUp,
+		os: &testOS{
+			t: "linux",
+		},
+		cpu: testCPU{
+			topo: testTopo{
+				cores:   1,
+				threads: 1,
+				sockets: 1,
+			},
+		},
+		memory:       1073741824,
+		memoryPolicy: testMemoryPolicy{},
+	}
+	resourceData := schema.TestResourceDataRaw(t, vmSchema, map[string]interface{}{
+		"memory":                   2147483648,
+		"pending_next_run_changes": []interface{}{"memory"},
+	})
+	diags := vmResourceUpdate(vm, resourceData)
+	if len(diags) != 0 {
+		t.Fatalf("failed to convert VM resource (%v)", diags)
+	}
+	compareResourceInt(t, resourceData, "memory", 2147483648)
+	compareResourceInt(t, resourceData, "cpu_cores", 1)
+
+	vm.status = ovirtclient.VMStatusDown
+	diags = vmResourceUpdate(vm, resourceData)
+	if len(diags) != 0 {
+		t.Fatalf("failed to convert VM resource (%v)", diags)
+	}
+	compareResourceInt(t, resourceData, "memory", int(vm.memory))
+	if pending := resourceData.Get("pending_next_run_changes").([]interface{}); len(pending) != 0 {
+		t.Fatalf("pending next run changes not cleared for stopped VM: %v", pending)
+	}
+}
+
+func TestVMResourceUpdatePendingNextRunChangesAppliedOutOfBand(t *testing.T) {
+	t.Parallel()
+
+	// The VM has been restarted outside of Terraform, so it is still up, but already runs with the desired memory.
+	vm := &testVM{
+		id:         "asdf",
+		name:       "test VM",
+		clusterID:  "cluster-1",
+		templateID: "template-1",
+		status:     ovirtclient.VMStatusUp,
+		os: &testOS{
+			t: "linux",
+		},
+		cpu: testCPU{
+			topo: testTopo{
+				cores:   1,
+				threads: 1,
+				sockets: 1,
+			},
+		},
+		memory:       2147483648,
+		memoryPolicy: testMemoryPolicy{},
+	}
+	resourceData := schema.TestResourceDataRaw(t, vmSchema, map[string]interface{}{
+		"memory":                   2147483648,
+		"cpu_cores":                2,
+		"cpu_threads":              1,
+		"cpu_sockets":              1,
+		"pending_next_run_changes": []interface{}{"cpu_cores", "memory"},
+	})
+	diags := vmResourceUpdate(vm, resourceData)
+	if len(diags) != 0 {
+		t.Fatalf("failed to convert VM resource (%v)", diags)
+	}
+	pending := resourceData.Get("pending_next_run_changes").([]interface{})
+	if len(pending) != 1 || pending[0] != "cpu_cores" {
+		t.Fatalf("incorrect pending next run changes: %v", pending)
+	}
+	compareResourceInt(t, resourceData, "memory", 2147483648)
+	compareResourceInt(t, resourceData, "cpu_cores", 2)
+}
+
+func TestVMRestartPowersOffIgnoredShutdown(t *testing.T) {
+	t.Parallel()
+
+	// Special case: we are using the ovirtclientlog.NewTestLogger here because we call the client methods outside of
+	// the Terraform context.
+	helper := newProvider(ovirtclientlog.NewTestLogger(t)).getTestHelper()
+	client := helper.GetClient()
+	vm, err := client.CreateVM(
+		helper.GetClusterID(),
+		helper.GetBlankTemplateID(),
+		helper.GenerateTestResourceName(t),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("failed to create test VM (%v)", err)
+	}
+	if err := client.StartVM(vm.ID()); err != nil {
+		t.Fatalf("failed to start test VM (%v)", err)
+	}
+	if _, err := client.WaitForVMStatus(vm.ID(), ovirtclient.VMStatusUp); err != nil {
+		t.Fatalf("failed to wait for test VM to start (%v)", err)
+	}
+
+	restartedVM, diags := vmRestart(
+		context.Background(),
+		&shutdownIgnoringClient{client},
+		vm.ID(),
+		100*time.Millisecond,
+	)
+	if diags.HasError() {
+		t.Fatalf("failed to restart VM (%v)", diags)
+	}
+	if restartedVM.Status() != ovirtclient.VMStatusUp {
+		t.Fatalf("VM is not up after the restart: %s", restartedVM.Status())
+	}
+}
+
+func TestVMUpdateRestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	for name, testCase := range map[string]struct {
+		stateRestartPolicy VMRestartPolicy
+		restartPolicy      VMRestartPolicy
+		comment            string
+		memory             int
+		nextRunMemory      bool
+		expectRestart      bool
+		expectedPending    int
+	}{
+		"always restarts on a comment change": {
+			VMRestartPolicyAlways, VMRestartPolicyAlways, "changed", 1073741824, false, true, 0,
+		},
+		"always does not restart when only the policy changes": {
+			VMRestartPolicyNever, VMRestartPolicyAlways, "", 1073741824, false, false, 0,
+		},
+		"when_required restarts if memory is saved for the next run": {
+			VMRestartPolicyWhenRequired, VMRestartPolicyWhenRequired, "", 2147483648, true, true, 0,
+		},
+		"when_required does not restart without pending changes": {
+			VMRestartPolicyWhenRequired, VMRestartPolicyWhenRequired, "changed", 1073741824, false, false, 0,
+		},
+		"never does not restart if memory is saved for the next run": {
+			VMRestartPolicyNever, VMRestartPolicyNever, "", 2147483648, true, false, 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Special case: we are using the ovirtclientlog.NewTestLogger here because we call the client methods
+			// outside of the Terraform context.
+			p := newProvider(ovirtclientlog.NewTestLogger(t)).(*provider)
+			helper := p.getTestHelper()
+			client := helper.GetClient()
+			vm, err := client.CreateVM(
+				helper.GetClusterID(),
+				helper.GetBlankTemplateID(),
+				helper.GenerateTestResourceName(t),
+				ovirtclient.NewCreateVMParams().MustWithMemory(1073741824),
+			)
+			if err != nil {
+				t.Fatalf("failed to create test VM (%v)", err)
+			}
+			if err := client.StartVM(vm.ID()); err != nil {
+				t.Fatalf("failed to start test VM (%v)", err)
+			}
+			if _, err := client.WaitForVMStatus(vm.ID(), ovirtclient.VMStatusUp); err != nil {
+				t.Fatalf("failed to wait for test VM to start (%v)", err)
+			}
+			restartClient := &restartCountingClient{Client: client}
+			if testCase.nextRunMemory {
+				restartClient.runningMemory = 1073741824
+			}
+			p.client = restartClient
+
+			state := schema.TestResourceDataRaw(t, vmSchema, map[string]interface{}{})
+			state.SetId(string(vm.ID()))
+			if diags := p.vmRead(context.Background(), state, nil); diags.HasError() {
+				t.Fatalf("failed to read test VM (%v)", diags)
+			}
+			if err := state.Set("restart_policy", string(testCase.stateRestartPolicy)); err != nil {
+				t.Fatalf("failed to set restart policy (%v)", err)
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"cluster_id":     string(vm.ClusterID()),
+				"template_id":    string(vm.TemplateID()),
+				"name":           vm.Name(),
+				"comment":        testCase.comment,
+				"memory":         testCase.memory,
+				"restart_policy": string(testCase.restartPolicy),
+			})
+			instanceState := state.State()
+			// Terraform records empty lists in the state, which ResourceData.State() leaves out.
+			instanceState.Attributes["initialization_nic_configuration.#"] = "0"
+			diff, err := p.vmResource().Diff(context.Background(), instanceState, config, p)
+			if err != nil {
+				t.Fatalf("failed to compute diff (%v)", err)
+			}
+			data, err := schema.InternalMap(vmSchema).Data(instanceState, diff)
+			if err != nil {
+				t.Fatalf("failed to create resource data (%v)", err)
+			}
+
+			diags := p.vmUpdate(context.Background(), data, nil)
+			if diags.HasError() {
+				t.Fatalf("failed to update VM (%v)", diags)
+			}
+			expectedRestarts := 0
+			if testCase.expectRestart {
+				expectedRestarts = 1
+			}
+			if restartClient.shutdowns != expectedRestarts || restartClient.starts != expectedRestarts {
+				t.Fatalf(
+					"incorrect number of restarts, shutdowns: %d, starts: %d, expected: %d",
+					restartClient.shutdowns,
+					restartClient.starts,
+					expectedRestarts,
+				)
+			}
+			if pending := data.Get("pending_next_run_changes").([]interface{}); len(pending) != testCase.expectedPending {
+				t.Fatalf("incorrect pending next run changes: %v", pending)
+			}
+		})
+	}
+}
+
+func TestVMReportedIPsResourceUpdateFailure(t *testing.T) {
+	t.Parallel()
+
+	vm := &testVM{
+		id:     "asdf",
+		status: ovirtclient.VMStatusUp,
+	}
+	resourceData := schema.TestResourceDataRaw(t, vmSchema, map[string]interface{}{})
+	if err := resourceData.Set("reported_ips", map[string]interface{}{"eth0": "192.0.2.1"}); err != nil {
+		t.Fatalf("failed to set reported IPs (%v)", err)
+	}
+	diags := vmReportedIPsResourceUpdate(&ipFailingClient{}, vm, resourceData, nil)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got: %v", diags)
+	}
+	if reportedIPs := resourceData.Get("reported_ips").(map[string]interface{}); len(reportedIPs) != 0 {
+		t.Fatalf("outdated reported IPs were kept: %v", reportedIPs)
+	}
+}
+
+// ipFailingClient simulates an engine that fails to return the IP addresses of a VM.
+type ipFailingClient struct {
+	ovirtclient.Client
+}
+
+func (c *ipFailingClient) GetVMIPAddresses(
+	_ ovirtclient.VMID,
+	_ ovirtclient.VMIPSearchParams,
+	_ ...ovirtclient.RetryStrategy,
+) (map[string][]net.IP, error) {
+	return nil, fmt.Errorf("guest agent not responding")
+}
+
+// shutdownIgnoringClient simulates a guest that ignores ACPI shutdown requests.
+type shutdownIgnoringClient struct {
+	ovirtclient.Client
+}
+
+func (c *shutdownIgnoringClient) ShutdownVM(_ ovirtclient.VMID, _ bool, _ ...ovirtclient.RetryStrategy) error {
+	return nil
+}
+
+// restartCountingClient counts the calls that restart a VM. If runningMemory is set, it simulates an engine that
+// saves memory changes for the next run: VMs report runningMemory until they are started again.
+type restartCountingClient struct {
+	ovirtclient.Client
+
+	runningMemory int64
+	nextRunMemory int64
+	shutdowns     int
+	starts        int
+}
+
+func (c *restartCountingClient) WithContext(_ context.Context) ovirtclient.Client {
+	return c
+}
+
+func (c *restartCountingClient) UpdateVM(
+	id ovirtclient.VMID,
+	params ovirtclient.UpdateVMParameters,
+	retries ...ovirtclient.RetryStrategy,
+) (ovirtclient.VM, error) {
+	vm, err := c.Client.UpdateVM(id, params, retries...)
+	if err != nil {
+		return nil, err
+	}
+	if memory := params.Memory(); memory != nil {
+		c.nextRunMemory = *memory
+	}
+	return c.reportedVM(vm), nil
+}
+
+func (c *restartCountingClient) WaitForVMStatus(
+	id ovirtclient.VMID,
+	status ovirtclient.VMStatus,
+	retries ...ovirtclient.RetryStrategy,
+) (ovirtclient.VM, error) {
+	vm, err := c.Client.WaitForVMStatus(id, status, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return c.reportedVM(vm), nil
+}
+
+func (c *restartCountingClient) ShutdownVM(
+	id ovirtclient.VMID,
+	force bool,
+	retries ...ovirtclient.RetryStrategy,
+) error {
+	c.shutdowns++
+	return c.Client.ShutdownVM(id, force, retries...)
+}
+
+func (c *restartCountingClient) StartVM(id ovirtclient.VMID, retries ...ovirtclient.RetryStrategy) error {
+	c.starts++
+	if c.runningMemory != 0 {
+		c.runningMemory = c.nextRunMemory
+	}
+	return c.Client.StartVM(id, retries...)
+}
+
+func (c *restartCountingClient) reportedVM(vm ovirtclient.VM) ovirtclient.VM {
+	if c.runningMemory == 0 {
+		return vm
+	}
+	return &runningMemoryVM{VM: vm, memory: c.runningMemory}
+}
+
+type runningMemoryVM struct {
+	ovirtclient.VM
+
+	memory int64
+}
+
+func (v *runningMemoryVM) Memory() int64 {
+	return v.memory
 }
 
 func compareResource(t *testing.T, data *schema.ResourceData, field string, value string) {
@@ -656,6 +1244,12 @@ func compareResource(t *testing.T, data *schema.ResourceData, field string, valu
 	}
 }
 
+func compareResourceInt(t *testing.T, data *schema.ResourceData, field string, value int) {
+	if resourceValue := data.Get(field); resourceValue != value {
+		t.Fatalf("invalid resource %s: %v, expected: %d", field, resourceValue, value)
+	}
+}
+
 func compareResourceStringList(t *testing.T, data *schema.ResourceData, field string, expectedValues []string) {
 	resourceValue := data.Get(field).(*schema.Set)
 	realValues := resourceValue.List()
@@ -1297,3 +1891,132 @@ func TestHugePages(t *testing.T) {
 		},
 	)
 }
+
+func TestVMResourceRestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	testHelper := p.getTestHelper()
+	clusterID := testHelper.GetClusterID()
+	templateID := testHelper.GetBlankTemplateID()
+	name := testHelper.GenerateTestResourceName(t)
+	configTemplate := `
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_vm" "foo" {
+	cluster_id     = "%s"
+	template_id    = "%s"
+	name           = "%s"
+	memory         = %d
+	restart_policy = "%s"
+}
+
+resource "ovirt_vm_start" "foo" {
+	vm_id = ovirt_vm.foo.id
+}
+`
+
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(configTemplate, clusterID, templateID, name, 1073741824, VMRestartPolicyNever),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "restart_policy", string(VMRestartPolicyNever)),
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "pending_next_run_changes.#", "0"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(configTemplate, clusterID, templateID, name, 2147483648, VMRestartPolicyNever),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "status", string(ovirtclient.VMStatusUp)),
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "pending_next_run_changes.#", "1"),
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "pending_next_run_changes.0", "memory"),
+					),
+				},
+				{
+					// The mock backend does not store memory changes, so reverting to the original value clears the
+					// pending change without a restart. TestVMUpdateRestartPolicy covers the restarts themselves.
+					Config: fmt.Sprintf(configTemplate, clusterID, templateID, name, 1073741824, VMRestartPolicyWhenRequired),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "status", string(ovirtclient.VMStatusUp)),
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "pending_next_run_changes.#", "0"),
+					),
+				},
+				{
+					Config:  fmt.Sprintf(configTemplate, clusterID, templateID, name, 1073741824, VMRestartPolicyWhenRequired),
+					Destroy: true,
+				},
+			},
+		},
+	)
+}
+
+func TestVMResourceRuntimeAttributes(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	testHelper := p.getTestHelper()
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_vm" "foo" {
+	cluster_id  = "%s"
+	template_id = "%s"
+	name        = "%s"
+}
+
+resource "ovirt_vm_start" "foo" {
+	vm_id = ovirt_vm.foo.id
+}
+`,
+		testHelper.GetClusterID(),
+		testHelper.GetBlankTemplateID(),
+		testHelper.GenerateTestResourceName(t),
+	)
+
+	resource.UnitTest(
+		t, resource.TestCase{
+			ProviderFactories: p.getProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "host_id", ""),
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "reported_ips.%", "0"),
+					),
+				},
+				{
+					// The second apply refreshes the VM after it has been started.
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("ovirt_vm.foo", "status", string(ovirtclient.VMStatusUp)),
+						resource.TestCheckResourceAttrSet("ovirt_vm.foo", "host_id"),
+						func(state *terraform.State) error {
+							vmID := state.RootModule().Resources["ovirt_vm.foo"].Primary.ID
+							vm, err := testHelper.GetClient().GetVM(ovirtclient.VMID(vmID))
+							if err != nil {
+								return err
+							}
+							hostID := state.RootModule().Resources["ovirt_vm.foo"].Primary.Attributes["host_id"]
+							if vm.HostID() == nil || string(*vm.HostID()) != hostID {
+								return fmt.Errorf("incorrect host ID in state: %s", hostID)
+							}
+							return nil
+						},
+					),
+				},
+				{
+					Config:  config,
+					Destroy: true,
+				},
+			},
+		},
+	)
+}
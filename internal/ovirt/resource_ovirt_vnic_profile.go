@@ -2,6 +2,7 @@ package ovirt
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,10 +25,11 @@ var vnicProfileSchema = map[string]*schema.Schema{
 		ValidateDiagFunc: validateUUID,
 	},
 	"pass_through": {
-		Type:        schema.TypeString,
-		Required:    true,
-		ForceNew:    true,
-		Description: "Indicates whether the VNIC profile is pass-through.",
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "Indicates whether the VNIC profile is pass-through. One of: enabled, disabled.",
+		ValidateDiagFunc: validateEnum(vnicProfilePassThroughValues()),
 	},
 	"port_mirroring": {
 		Type:        schema.TypeBool,
@@ -35,20 +37,31 @@ var vnicProfileSchema = map[string]*schema.Schema{
 		ForceNew:    true,
 		Description: "Indicates whether port mirroring is enabled for the VNIC profile.",
 	},
+	"description": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     "",
+		Description: "Description of the VNIC profile.",
+	},
 	"id": {
 		Type:     schema.TypeString,
 		Computed: true,
 	},
 }
 
+func vnicProfilePassThroughValues() []string {
+	return []string{"enabled", "disabled"}
+}
+
 func (p *provider) vnicProfileResource() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: p.vnicProfileCreate,
 		ReadContext:   p.vnicProfileRead,
 		DeleteContext: p.vnicProfileDelete,
-		// Importer: &schema.ResourceImporter{
-		// 	StateContext: p.vnicProfileImport,
-		// },
+		Importer: &schema.ResourceImporter{
+			StateContext: p.vnicProfileImport,
+		},
 		Schema:      vnicProfileSchema,
 		Description: "The ovirt_vnic_profile resource creates VNIC profiles in oVirt.",
 	}
@@ -60,7 +73,11 @@ func (p *provider) vnicProfileCreate(ctx context.Context, data *schema.ResourceD
 	networkID := data.Get("network_id").(string)
 	passThrough := data.Get("pass_through").(string)
 	portMirroring := data.Get("port_mirroring").(bool)
+	description := data.Get("description").(string)
 	params := ovirtclient.CreateVNICProfileParams()
+	if description != "" {
+		params = params.WithDescription(description)
+	}
 	if passThrough != "" {
 		params = params.WithPassThrough(passThrough)
 	}
@@ -79,6 +96,10 @@ func (p *provider) vnicProfileRead(ctx context.Context, data *schema.ResourceDat
 	vnicProfileID := data.Id()
 	vnicProfile, err := client.GetVNICProfile(ovirtclient.VNICProfileID(vnicProfileID))
 	if err != nil {
+		if isNotFound(err) {
+			data.SetId("")
+			return nil
+		}
 		return errorToDiags("read VNIC profile", err)
 	}
 	return vnicProfileResourceUpdate(vnicProfile, data)
@@ -96,6 +117,21 @@ func (p *provider) vnicProfileDelete(ctx context.Context, data *schema.ResourceD
 	return nil
 }
 
+func (p *provider) vnicProfileImport(ctx context.Context, data *schema.ResourceData, _ interface{}) (
+	[]*schema.ResourceData,
+	error,
+) {
+	client := p.client.WithContext(ctx)
+	vnicProfile, err := client.GetVNICProfile(ovirtclient.VNICProfileID(data.Id()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to import VNIC profile %s (%w)", data.Id(), err)
+	}
+	if err := diagsToError(vnicProfileResourceUpdate(vnicProfile, data)); err != nil {
+		return nil, fmt.Errorf("failed to import VNIC profile %s (%w)", data.Id(), err)
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
 func vnicProfileResourceUpdate(vnicProfile ovirtclient.VNICProfile, data *schema.ResourceData) diag.Diagnostics {
 	if err := data.Set("name", vnicProfile.Name()); err != nil {
 		return diag.FromErr(err)
@@ -109,6 +145,9 @@ func vnicProfileResourceUpdate(vnicProfile ovirtclient.VNICProfile, data *schema
 	if err := data.Set("port_mirroring", vnicProfile.PortMirroring()); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := data.Set("description", vnicProfile.Description()); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := data.Set("id", string(vnicProfile.ID())); err != nil {
 		return diag.FromErr(err)
 	}
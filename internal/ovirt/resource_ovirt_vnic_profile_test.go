@@ -0,0 +1,123 @@
+package ovirt
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestVNICProfileResourceImport(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t))
+	client := p.getTestHelper().GetClient()
+	defaultProfile, err := client.GetVNICProfile(p.getTestHelper().GetVNICProfileID())
+	if err != nil {
+		t.Fatalf("Failed to get VNIC profile (%v)", err)
+	}
+	vnicProfile, err := client.CreateVNICProfile(
+		p.getTestHelper().GenerateTestResourceName(t),
+		defaultProfile.NetworkID(),
+		ovirtclient.CreateVNICProfileParams(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create VNIC profile (%v)", err)
+	}
+
+	config := fmt.Sprintf(
+		`
+provider "ovirt" {
+	mock = true
+}
+
+resource "ovirt_vnic_profile" "test" {
+	name           = "%s"
+	network_id     = "%s"
+	pass_through   = "disabled"
+	port_mirroring = false
+}
+`,
+		vnicProfile.Name(),
+		vnicProfile.NetworkID(),
+	)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: p.getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateId:      string(vnicProfile.ID()),
+				ResourceName:       "ovirt_vnic_profile.test",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					if name := states[0].Attributes["name"]; name != vnicProfile.Name() {
+						return fmt.Errorf("incorrect imported name: %s", name)
+					}
+					if networkID := states[0].Attributes["network_id"]; networkID != string(vnicProfile.NetworkID()) {
+						return fmt.Errorf("incorrect imported network_id: %s", networkID)
+					}
+					return nil
+				},
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestVNICProfileResourceReadNotFound(t *testing.T) {
+	t.Parallel()
+
+	p := newProvider(newTestLogger(t)).(*provider)
+	p.client = p.getTestHelper().GetClient()
+	defaultProfile, err := p.client.GetVNICProfile(p.getTestHelper().GetVNICProfileID())
+	if err != nil {
+		t.Fatalf("Failed to get VNIC profile (%v)", err)
+	}
+	vnicProfile, err := p.client.CreateVNICProfile(
+		p.getTestHelper().GenerateTestResourceName(t),
+		defaultProfile.NetworkID(),
+		ovirtclient.CreateVNICProfileParams(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create VNIC profile (%v)", err)
+	}
+	if err := p.client.RemoveVNICProfile(vnicProfile.ID()); err != nil {
+		t.Fatalf("Failed to remove VNIC profile (%v)", err)
+	}
+
+	data := schema.TestResourceDataRaw(t, vnicProfileSchema, map[string]interface{}{})
+	data.SetId(string(vnicProfile.ID()))
+	if diags := p.vnicProfileRead(context.Background(), data, nil); diags.HasError() {
+		t.Fatalf("reading a removed VNIC profile returned an error (%v)", diags)
+	}
+	if data.Id() != "" {
+		t.Fatalf("reading a removed VNIC profile did not clear the ID (%s)", data.Id())
+	}
+}
+
+func TestVNICProfileResourcePassThroughValidation(t *testing.T) {
+	t.Parallel()
+
+	validate := vnicProfileSchema["pass_through"].ValidateDiagFunc
+	for _, value := range vnicProfilePassThroughValues() {
+		if diags := validate(value, cty.Path{}); diags.HasError() {
+			t.Fatalf("valid pass_through value %s was rejected (%v)", value, diags)
+		}
+	}
+	if diags := validate("true", cty.Path{}); !diags.HasError() {
+		t.Fatalf("invalid pass_through value was accepted")
+	}
+}
@@ -2,10 +2,13 @@ package ovirt
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -262,6 +265,167 @@ func validateUUID(i interface{}, path cty.Path) diag.Diagnostics {
 	return nil
 }
 
+func validateIPAddress(i interface{}, path cty.Path) diag.Diagnostics {
+	val, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a string",
+				Detail:        "The specified value is not a string, but must be a string containing an IP address.",
+				AttributePath: path,
+			},
+		}
+	}
+	if net.ParseIP(val) == nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not an IP address",
+				Detail:        fmt.Sprintf("The specified value is not a valid IPv4 or IPv6 address: %s", val),
+				AttributePath: path,
+			},
+		}
+	}
+	return nil
+}
+
+func validateIPv4Netmask(i interface{}, path cty.Path) diag.Diagnostics {
+	val, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a string",
+				Detail:        "The specified value is not a string, but must be a string containing an IPv4 netmask.",
+				AttributePath: path,
+			},
+		}
+	}
+	if prefixLength, err := strconv.ParseUint(val, 10, 8); err == nil && prefixLength <= 32 {
+		return nil
+	}
+	if ip := net.ParseIP(val).To4(); ip != nil && !strings.Contains(val, ":") {
+		if ones, bits := net.IPMask(ip).Size(); ones != 0 || bits != 0 {
+			return nil
+		}
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Not an IPv4 netmask",
+			Detail: fmt.Sprintf(
+				"The specified value is not a valid IPv4 netmask in dotted quad notation or a prefix length between 0 and 32: %s",
+				val,
+			),
+			AttributePath: path,
+		},
+	}
+}
+
+func validateIPv6PrefixLength(i interface{}, path cty.Path) diag.Diagnostics {
+	val, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a string",
+				Detail:        "The specified value is not a string, but must be a string containing an IPv6 prefix length.",
+				AttributePath: path,
+			},
+		}
+	}
+	if prefixLength, err := strconv.ParseUint(val, 10, 8); err != nil || prefixLength > 128 {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not an IPv6 prefix length",
+				Detail:        fmt.Sprintf("The specified value is not a prefix length between 0 and 128: %s", val),
+				AttributePath: path,
+			},
+		}
+	}
+	return nil
+}
+
+func validateMACAddress(i interface{}, path cty.Path) diag.Diagnostics {
+	val, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a string",
+				Detail:        "The specified value is not a string, but must be a string containing a MAC address.",
+				AttributePath: path,
+			},
+		}
+	}
+	if _, err := net.ParseMAC(val); err != nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a MAC address",
+				Detail:        fmt.Sprintf("The specified value is not a valid MAC address: %s", val),
+				AttributePath: path,
+			},
+		}
+	}
+	return nil
+}
+
+func validateHTTPURL(i interface{}, path cty.Path) diag.Diagnostics {
+	val, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a string",
+				Detail:        "The specified value is not a string, but must be a string containing an HTTP or HTTPS URL.",
+				AttributePath: path,
+			},
+		}
+	}
+	u, err := url.Parse(val)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not an HTTP URL",
+				Detail:        fmt.Sprintf("The specified value is not a valid HTTP or HTTPS URL: %s", val),
+				AttributePath: path,
+			},
+		}
+	}
+	return nil
+}
+
+var sha256Regexp = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+func validateSHA256(i interface{}, path cty.Path) diag.Diagnostics {
+	val, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a string",
+				Detail:        "The specified value is not a string, but must be a string containing a SHA-256 checksum.",
+				AttributePath: path,
+			},
+		}
+	}
+	if !sha256Regexp.MatchString(val) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Not a SHA-256 checksum",
+				Detail:        fmt.Sprintf("The specified value is not a hex-encoded SHA-256 checksum: %s", val),
+				AttributePath: path,
+			},
+		}
+	}
+	return nil
+}
+
 func validatePositiveInt(i interface{}, path cty.Path) diag.Diagnostics {
 	val, ok := i.(int)
 	if !ok {
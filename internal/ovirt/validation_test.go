@@ -0,0 +1,60 @@
+package ovirt
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateIPv4Netmask(t *testing.T) {
+	t.Parallel()
+
+	for value, valid := range map[string]bool{
+		"255.255.255.0":   true,
+		"255.255.255.255": true,
+		"0.0.0.0":         true,
+		"255.255.0.0":     true,
+		"0":               true,
+		"24":              true,
+		"32":              true,
+		"":                false,
+		"33":              false,
+		"-1":              false,
+		"+24":             false,
+		"255.0.255.0":     false,
+		"255.255.255":     false,
+		"256.255.255.0":   false,
+		"::ffff:ff00:0":   false,
+		"netmask":         false,
+	} {
+		if diags := validateIPv4Netmask(value, cty.Path{}); diags.HasError() == valid {
+			t.Fatalf("incorrect validation result for %q, expected valid: %t, got: %v", value, valid, diags)
+		}
+	}
+	if diags := validateIPv4Netmask(24, cty.Path{}); !diags.HasError() {
+		t.Fatalf("no error returned for a non-string value")
+	}
+}
+
+func TestValidateIPv6PrefixLength(t *testing.T) {
+	t.Parallel()
+
+	for value, valid := range map[string]bool{
+		"0":             true,
+		"64":            true,
+		"128":           true,
+		"":              false,
+		"129":           false,
+		"256":           false,
+		"-1":            false,
+		"ffff:ffff::":   false,
+		"255.255.255.0": false,
+	} {
+		if diags := validateIPv6PrefixLength(value, cty.Path{}); diags.HasError() == valid {
+			t.Fatalf("incorrect validation result for %q, expected valid: %t, got: %v", value, valid, diags)
+		}
+	}
+	if diags := validateIPv6PrefixLength(64, cty.Path{}); !diags.HasError() {
+		t.Fatalf("no error returned for a non-string value")
+	}
+}